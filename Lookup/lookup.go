@@ -1,50 +1,337 @@
-package Lookup
-
-import (
-	//user defined package(s)
-	"online/dbUpdates"
-	"online/models"
-
-	//Inbuild package(s)
-	"fmt"
-	"log"
-	"os"
-	"path"
-	"strings"
-
-	//Third party package(s)
-	"gorm.io/gorm"
-)
-
-func UpdateDatabase(Db *gorm.DB) {
-	var update []models.Updates
-	var check bool
-	files, err := os.ReadDir("./dbUpdates")
-	if err != nil {
-		log.Println("Error :", err)
-		return
-	}
-	Db.AutoMigrate(&models.Updates{})
-	Db.Find(&update)
-	for i := 0; i < len(files); i++ {
-		for _, value := range update {
-			file := fmt.Sprintf("%s.go", value.FileName)
-			if files[i].Name() == file {
-				check = true
-				break
-			}
-		}
-		if !check && files[i].Name() != "update.go" {
-			var update1 models.Updates
-			extension := path.Ext(files[i].Name())
-			index := strings.LastIndex(files[i].Name(), extension)
-			update1.FileName = files[i].Name()[:index]
-			Db.Create(&update1)
-			log.Println(update1.FileName, "is updated...")
-			update := dbUpdates.Update{}
-			update.Invoke(update1.FileName)
-		}
-		check = false
-	}
-
-}
+package Lookup
+
+import (
+	//user defined package(s)
+	"online/dbUpdates"
+	"online/logs"
+	"online/models"
+
+	//Inbuild package(s)
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	//Third party package(s)
+	"gorm.io/gorm"
+)
+
+// Key of the postgres advisory lock held while migrating, so two instances
+// starting together don't apply the same migration twice (SQLite already
+// serializes writers on the database file)
+const lockKey = 4823017
+
+// A versioned migration embedded from dbUpdates
+type Migration struct {
+	Version  uint
+	Name     string
+	Up       string
+	Down     string
+	Checksum string
+}
+
+// File name of the migration without its direction and extension
+func (m Migration) File() string {
+	return fmt.Sprintf("%04d_%s", m.Version, m.Name)
+}
+
+// Load the migrations of a dialect ('postgres' or 'sqlite') ordered by version
+func Load(dialect string) ([]Migration, error) {
+	files, err := fs.ReadDir(dbUpdates.Files, dialect)
+	if err != nil {
+		return nil, fmt.Errorf("no migrations for %s database: %w", dialect, err)
+	}
+	byVersion := map[uint]*Migration{}
+	for _, file := range files {
+		name := file.Name()
+		var direction string
+		switch {
+		case strings.HasSuffix(name, ".up.sql"):
+			direction = "up"
+		case strings.HasSuffix(name, ".down.sql"):
+			direction = "down"
+		default:
+			continue
+		}
+		base := strings.TrimSuffix(name, "."+direction+".sql")
+		prefix, label, found := strings.Cut(base, "_")
+		version, err := strconv.ParseUint(prefix, 10, 32)
+		if !found || err != nil {
+			return nil, fmt.Errorf("invalid migration file name %q", name)
+		}
+		content, err := fs.ReadFile(dbUpdates.Files, path.Join(dialect, name))
+		if err != nil {
+			return nil, err
+		}
+		migration, ok := byVersion[uint(version)]
+		if !ok {
+			migration = &Migration{Version: uint(version), Name: label}
+			byVersion[uint(version)] = migration
+		} else if migration.Name != label {
+			return nil, fmt.Errorf("migration version %d is used by both %q and %q", version, migration.Name, label)
+		}
+		if direction == "up" {
+			sum := sha256.Sum256(content)
+			migration.Up, migration.Checksum = string(content), hex.EncodeToString(sum[:])
+		} else {
+			migration.Down = string(content)
+		}
+	}
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("migration %s has no up file", migration.File())
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Run fc on a single connection holding the migration lock, with the
+// schema_migrations table in place
+func withLock(Db *gorm.DB, fc func(conn *gorm.DB) error) error {
+	return Db.Connection(func(conn *gorm.DB) error {
+		if conn.Dialector.Name() == "postgres" {
+			if err := conn.Exec("SELECT pg_advisory_lock(?)", lockKey).Error; err != nil {
+				return fmt.Errorf("acquire migration lock: %w", err)
+			}
+			defer conn.Exec("SELECT pg_advisory_unlock(?)", lockKey)
+		}
+		if err := conn.AutoMigrate(&models.SchemaMigration{}); err != nil {
+			return fmt.Errorf("create schema_migrations table: %w", err)
+		}
+		return fc(conn)
+	})
+}
+
+// Read the applied migrations and verify that none of them changed since
+func applied(conn *gorm.DB, migrations []Migration) (map[uint]models.SchemaMigration, error) {
+	var rows []models.SchemaMigration
+	if !conn.Migrator().HasTable(&models.SchemaMigration{}) {
+		return map[uint]models.SchemaMigration{}, nil
+	}
+	if err := conn.Order("version").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	done := make(map[uint]models.SchemaMigration, len(rows))
+	for _, row := range rows {
+		done[row.Version] = row
+	}
+	for _, migration := range migrations {
+		if row, ok := done[migration.Version]; ok && row.Checksum != migration.Checksum {
+			return nil, fmt.Errorf("migration %s was modified after it was applied (checksum %s, applied %s)",
+				migration.File(), migration.Checksum, row.Checksum)
+		}
+	}
+	return done, nil
+}
+
+// Apply the pending migrations in order, each in its own transaction.
+// When limit > 0 at most limit migrations are applied.
+func Up(Db *gorm.DB, limit int) (done []Migration, err error) {
+	log := logs.Log()
+	migrations, err := Load(Db.Dialector.Name())
+	if err != nil {
+		return nil, err
+	}
+	err = withLock(Db, func(conn *gorm.DB) error {
+		applied, err := applied(conn, migrations)
+		if err != nil {
+			return err
+		}
+		for _, migration := range migrations {
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+			if limit > 0 && len(done) == limit {
+				break
+			}
+			err := conn.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec(migration.Up).Error; err != nil {
+					return err
+				}
+				return tx.Create(&models.SchemaMigration{
+					Version:   migration.Version,
+					Name:      migration.Name,
+					Checksum:  migration.Checksum,
+					AppliedAt: time.Now(),
+				}).Error
+			})
+			if err != nil {
+				return fmt.Errorf("apply migration %s: %w", migration.File(), err)
+			}
+			log.Info("migration is applied", "migration", migration.File())
+			done = append(done, migration)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Roll back the latest applied migrations, newest first, each in its own
+// transaction. At most limit migrations are rolled back (one when limit <= 0).
+func Down(Db *gorm.DB, limit int) (done []Migration, err error) {
+	log := logs.Log()
+	if limit <= 0 {
+		limit = 1
+	}
+	migrations, err := Load(Db.Dialector.Name())
+	if err != nil {
+		return nil, err
+	}
+	err = withLock(Db, func(conn *gorm.DB) error {
+		applied, err := applied(conn, migrations)
+		if err != nil {
+			return err
+		}
+		for index := len(migrations) - 1; index >= 0 && len(done) < limit; index-- {
+			migration := migrations[index]
+			if _, ok := applied[migration.Version]; !ok {
+				continue
+			}
+			if migration.Down == "" {
+				return fmt.Errorf("migration %s has no down file", migration.File())
+			}
+			err := conn.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec(migration.Down).Error; err != nil {
+					return err
+				}
+				return tx.Where("version = ?", migration.Version).Delete(&models.SchemaMigration{}).Error
+			})
+			if err != nil {
+				return fmt.Errorf("roll back migration %s: %w", migration.File(), err)
+			}
+			log.Info("migration is rolled back", "migration", migration.File())
+			done = append(done, migration)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Roll back the latest applied migration and apply it again
+func Redo(Db *gorm.DB) ([]Migration, error) {
+	done, err := Down(Db, 1)
+	if err != nil || len(done) == 0 {
+		return nil, err
+	}
+	return Up(Db, 1)
+}
+
+// Apply every pending migration to the database
+func UpdateDatabase(Db *gorm.DB) error {
+	_, err := Up(Db, 0)
+	return err
+}
+
+// State of a migration in the database
+type MigrationStatus struct {
+	Migration
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// List every known migration and whether it is applied, oldest first
+func Status(Db *gorm.DB) ([]MigrationStatus, error) {
+	migrations, err := Load(Db.Dialector.Name())
+	if err != nil {
+		return nil, err
+	}
+	applied, err := applied(Db, migrations)
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]MigrationStatus, len(migrations))
+	for index, migration := range migrations {
+		row, ok := applied[migration.Version]
+		statuses[index] = MigrationStatus{Migration: migration, Applied: ok, AppliedAt: row.AppliedAt}
+	}
+	return statuses, nil
+}
+
+// Migrations that Up would apply, without applying them
+func Pending(Db *gorm.DB, limit int) (pending []Migration, err error) {
+	statuses, err := Status(Db)
+	if err != nil {
+		return nil, err
+	}
+	for _, status := range statuses {
+		if limit > 0 && len(pending) == limit {
+			break
+		}
+		if !status.Applied {
+			pending = append(pending, status.Migration)
+		}
+	}
+	return pending, nil
+}
+
+// Migrations that Down would roll back, newest first, without rolling them back
+func Rollbacks(Db *gorm.DB, limit int) (rollbacks []Migration, err error) {
+	if limit <= 0 {
+		limit = 1
+	}
+	statuses, err := Status(Db)
+	if err != nil {
+		return nil, err
+	}
+	for index := len(statuses) - 1; index >= 0 && len(rollbacks) < limit; index-- {
+		if statuses[index].Applied {
+			rollbacks = append(rollbacks, statuses[index].Migration)
+		}
+	}
+	return rollbacks, nil
+}
+
+// Create empty up and down files of the next version for every dialect under
+// dir (the dbUpdates source directory) and return their paths
+func Create(dir, name string) ([]string, error) {
+	name = strings.ToLower(strings.Join(strings.Fields(name), "_"))
+	if name == "" {
+		return nil, fmt.Errorf("migration name is empty")
+	}
+	var next uint64
+	dialects, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, dialect := range dialects {
+		if !dialect.IsDir() {
+			continue
+		}
+		files, err := os.ReadDir(filepath.Join(dir, dialect.Name()))
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			prefix, _, _ := strings.Cut(file.Name(), "_")
+			if version, err := strconv.ParseUint(prefix, 10, 32); err == nil && version > next {
+				next = version
+			}
+		}
+	}
+	next++
+	var created []string
+	for _, dialect := range dialects {
+		if !dialect.IsDir() {
+			continue
+		}
+		for _, direction := range []string{"up", "down"} {
+			file := filepath.Join(dir, dialect.Name(), fmt.Sprintf("%04d_%s.%s.sql", next, name, direction))
+			content := fmt.Sprintf("-- %04d_%s (%s) for %s\n", next, name, direction, dialect.Name())
+			if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+				return created, err
+			}
+			created = append(created, file)
+		}
+	}
+	return created, nil
+}
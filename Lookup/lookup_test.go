@@ -0,0 +1,163 @@
+package Lookup
+
+import (
+	//User defined package(s)
+	"online/models"
+
+	//Inbuild package(s)
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	//Third party package(s)
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+var databases int
+
+// Open an empty in-memory database private to the test
+func openDb(t *testing.T) *gorm.DB {
+	t.Helper()
+	databases++
+	dsn := fmt.Sprintf("file:%s_%d?mode=memory&cache=shared", t.Name(), databases)
+	Db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	sqlDb, _ := Db.DB()
+	t.Cleanup(func() { sqlDb.Close() })
+	return Db
+}
+
+func TestUpDown(t *testing.T) {
+	Db := openDb(t)
+	migrations, err := Load("sqlite")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	done, err := Up(Db, 0)
+	if err != nil {
+		t.Fatalf("up: %v", err)
+	}
+	if len(done) != len(migrations) {
+		t.Fatalf("expected %d applied migrations, got %d", len(migrations), len(done))
+	}
+	var roles []models.Roles
+	if err := Db.Find(&roles).Error; err != nil || len(roles) != 2 {
+		t.Fatalf("expected the 2 seeded roles, got %v (%v)", roles, err)
+	}
+
+	if done, err := Up(Db, 0); err != nil || len(done) != 0 {
+		t.Fatalf("second up should be a no-op, applied %d (%v)", len(done), err)
+	}
+
+	done, err = Down(Db, len(migrations))
+	if err != nil {
+		t.Fatalf("down: %v", err)
+	}
+	if len(done) != len(migrations) || done[0].Version != migrations[len(migrations)-1].Version {
+		t.Fatalf("expected all migrations rolled back newest first, got %v", done)
+	}
+	if Db.Migrator().HasTable(&models.Roles{}) {
+		t.Fatal("roles table still exists after rolling back")
+	}
+
+	if done, err := Up(Db, 1); err != nil || len(done) != 1 || done[0].Version != migrations[0].Version {
+		t.Fatalf("up 1 should apply only the first migration, got %v (%v)", done, err)
+	}
+}
+
+func TestModifiedMigration(t *testing.T) {
+	Db := openDb(t)
+	if err := UpdateDatabase(Db); err != nil {
+		t.Fatalf("up: %v", err)
+	}
+	Db.Model(&models.SchemaMigration{}).Where("version = ?", 1).Update("checksum", "changed")
+	if err := UpdateDatabase(Db); err == nil {
+		t.Fatal("expected an error for a migration modified after it was applied")
+	}
+}
+
+func TestExistingSchema(t *testing.T) {
+	//Databases created by the old runner already have the tables, the roles and 'updates'
+	Db := openDb(t)
+	Db.AutoMigrate(&models.Roles{}, &models.User{}, &models.Authentication{}, &models.ProductInfo{})
+	//The orders as they were before they held their state
+	Db.Exec(`CREATE TABLE order_product_infos (order_id integer PRIMARY KEY AUTOINCREMENT, user_id bigint,
+		brand_name varchar(100), product_price text, ram_capacity varchar(100), ram_price text, dvd_rw_drive numeric,
+		name varchar(50), address varchar(200), phone_number varchar(200), total_price text,
+		payment_status varchar(50) DEFAULT 'pending', created_at datetime, updated_at datetime, cancelled_at datetime)`)
+	Db.Exec(`CREATE TABLE order_statuses (order_id bigint, user_id bigint, payment_status varchar(50),
+		order_status varchar(50), created_at datetime, updated_at datetime, cancelled_at datetime)`)
+	Db.Create(&[]models.Roles{{RoleId: 1, Role: "admin"}, {RoleId: 2, Role: "user"}})
+	Db.Exec("CREATE TABLE updates (id integer PRIMARY KEY, file_name text)")
+
+	if err := UpdateDatabase(Db); err != nil {
+		t.Fatalf("up on an existing schema: %v", err)
+	}
+	if Db.Migrator().HasTable("updates") {
+		t.Fatal("'updates' table should be dropped")
+	}
+}
+
+func TestStatus(t *testing.T) {
+	Db := openDb(t)
+	if _, err := Up(Db, 1); err != nil {
+		t.Fatalf("up: %v", err)
+	}
+	statuses, err := Status(Db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !statuses[0].Applied || statuses[0].AppliedAt.IsZero() || statuses[1].Applied {
+		t.Fatalf("expected only the first migration applied, got %+v", statuses)
+	}
+	pending, _ := Pending(Db, 0)
+	if len(pending) != len(statuses)-1 || pending[0].Version != statuses[1].Version {
+		t.Fatalf("unexpected pending migrations %v", pending)
+	}
+	rollbacks, _ := Rollbacks(Db, 0)
+	if len(rollbacks) != 1 || rollbacks[0].Version != statuses[0].Version {
+		t.Fatalf("unexpected rollbacks %v", rollbacks)
+	}
+	if done, err := Redo(Db); err != nil || len(done) != 1 || done[0].Version != statuses[0].Version {
+		t.Fatalf("redo should reapply the first migration, got %v (%v)", done, err)
+	}
+}
+
+func TestCreate(t *testing.T) {
+	dir := t.TempDir()
+	for _, dialect := range []string{"postgres", "sqlite"} {
+		os.Mkdir(filepath.Join(dir, dialect), 0755)
+	}
+	os.WriteFile(filepath.Join(dir, "sqlite", "0007_old.up.sql"), nil, 0644)
+
+	files, err := Create(dir, "Add order events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 4 {
+		t.Fatalf("expected up and down files for 2 dialects, got %v", files)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "postgres", "0008_add_order_events.down.sql")); err != nil {
+		t.Fatalf("expected the next version to follow the latest one: %v", err)
+	}
+}
+
+func TestMigratesOnlyGivenDatabase(t *testing.T) {
+	main, other := openDb(t), openDb(t)
+
+	if err := UpdateDatabase(main); err != nil {
+		t.Fatalf("up: %v", err)
+	}
+	if statuses, err := Status(main); err != nil || !statuses[len(statuses)-1].Applied {
+		t.Fatalf("expected the migrations recorded in the migrated database, got %+v (%v)", statuses, err)
+	}
+	if other.Migrator().HasTable(&models.SchemaMigration{}) || other.Migrator().HasTable(&models.Roles{}) {
+		t.Fatal("migrating one database changed another one")
+	}
+}
@@ -0,0 +1,149 @@
+package apierror
+
+import (
+	//user defined package(s)
+	"online/logs"
+
+	//Inbuild package(s)
+	"errors"
+	"fmt"
+	"net/http"
+
+	//Third party package(s)
+	"github.com/labstack/echo"
+)
+
+// Machine-readable codes of the errors, documented in the Readme. Clients
+// should rely on these rather than on the messages.
+const (
+	CodeInvalidRequest   = "invalid_request"
+	CodeValidation       = "validation_failed"
+	CodeTokenMissing     = "token_missing"
+	CodeTokenInvalid     = "token_invalid"
+	CodeTokenExpired     = "token_expired"
+	CodeUnauthorized     = "unauthorized"
+	CodeUserExists       = "user_exists"
+	CodeUserNotFound     = "user_not_found"
+	CodeWrongPassword    = "incorrect_password"
+	CodeProductNotFound  = "product_not_found"
+	CodeOrderNotFound    = "order_not_found"
+	CodeNothingToUpdate  = "nothing_to_update"
+	CodePaymentMismatch  = "payment_mismatch"
+	CodeIllegalState     = "illegal_transition"
+	CodeNotFound         = "not_found"
+	CodeMethodNotAllowed = "method_not_allowed"
+	CodeInternal         = "internal_error"
+)
+
+// Problem with one field of a request
+type Detail struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Error answered by the API, as {"error": {...}} with the HTTP status Status
+type Error struct {
+	Status    int      `json:"-"`
+	Code      string   `json:"code"`
+	Message   string   `json:"message"`
+	Details   []Detail `json:"details,omitempty"`
+	RequestID string   `json:"request_id,omitempty"`
+
+	//Cause of the error, logged but never sent to the client
+	Err error `json:"-"`
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %s: %v", e.Code, e.Message, e.Err)
+	}
+	return e.Code + ": " + e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Create an error of the API
+func New(status int, code, message string, details ...Detail) *Error {
+	return &Error{Status: status, Code: code, Message: message, Details: details}
+}
+
+// Invalid or missing fields of a request
+func Validation(details ...Detail) *Error {
+	return New(http.StatusUnprocessableEntity, CodeValidation, "invalid request fields", details...)
+}
+
+// Unexpected failure, whose cause is only logged
+func Internal(err error) *Error {
+	e := New(http.StatusInternalServerError, CodeInternal, "internal server error")
+	e.Err = err
+	return e
+}
+
+// Codes of the errors raised by echo itself (unknown route, malformed body...)
+var statusCodes = map[int]string{
+	http.StatusBadRequest:           CodeInvalidRequest,
+	http.StatusUnauthorized:         CodeUnauthorized,
+	http.StatusNotFound:             CodeNotFound,
+	http.StatusMethodNotAllowed:     CodeMethodNotAllowed,
+	http.StatusUnsupportedMediaType: CodeInvalidRequest,
+}
+
+// Convert any error returned by a handler into an error of the API
+func From(err error) *Error {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr
+	}
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) && httpErr.Code < 500 {
+		code, ok := statusCodes[httpErr.Code]
+		if !ok {
+			code = CodeInvalidRequest
+		}
+		message, ok := httpErr.Message.(string)
+		if !ok {
+			message = http.StatusText(httpErr.Code)
+		}
+		return New(httpErr.Code, code, message)
+	}
+	return Internal(err)
+}
+
+// Echo error handler writing every error in the same JSON envelope
+func Handler(err error, c echo.Context) {
+	apiErr := *From(err)
+	apiErr.RequestID = c.Response().Header().Get(echo.HeaderXRequestID)
+
+	//The access log line of the request carries the code, and the cause of a
+	//server error
+	log := logs.Request(c).With("error_code", apiErr.Code)
+	if apiErr.Status >= 500 {
+		log.Error(apiErr.Message, "error", err)
+	}
+	logs.SetRequest(c, log)
+
+	if c.Response().Committed {
+		return
+	}
+	if c.Request().Method == http.MethodHead {
+		err = c.NoContent(apiErr.Status)
+	} else {
+		err = c.JSON(apiErr.Status, map[string]interface{}{"error": apiErr})
+	}
+	if err != nil {
+		log.Error("Error at writing the error response", "error", err)
+	}
+}
+
+// Run the rest of the chain and return the status of its response. An error
+// is written at once by the error handler of echo, and not returned, so the
+// logging, metrics and tracing middlewares around, in whatever order, all see
+// the status it was answered with and it is answered only once.
+func Serve(c echo.Context, next echo.HandlerFunc) int {
+	if err := next(c); err != nil {
+		c.Error(err)
+	}
+	return c.Response().Status
+}
@@ -0,0 +1,102 @@
+package apierror
+
+import (
+	//Inbuild package(s)
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	//Third party package(s)
+	"github.com/labstack/echo"
+)
+
+// Serve url and decode the error envelope of the response
+func serve(t *testing.T, method, url string) (int, Error) {
+	t.Helper()
+	e := echo.New()
+	e.HTTPErrorHandler = Handler
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Response().Header().Set(echo.HeaderXRequestID, "req-1")
+			return next(c)
+		}
+	})
+	e.GET("/field", func(c echo.Context) error {
+		return Validation(Detail{Field: "email", Message: "must be a valid email"})
+	})
+	e.GET("/failure", func(c echo.Context) error {
+		return errors.New("connection refused by db.internal:5432")
+	})
+	resp := httptest.NewRecorder()
+	e.ServeHTTP(resp, httptest.NewRequest(method, url, nil))
+	if strings.Contains(resp.Body.String(), "db.internal") {
+		t.Fatalf("the cause of an error must not be sent, got %s", resp.Body)
+	}
+	var body struct {
+		Error Error `json:"error"`
+	}
+	if err := json.Unmarshal(resp.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode %s: %v", resp.Body, err)
+	}
+	return resp.Code, body.Error
+}
+
+func TestHandler(t *testing.T) {
+	tests := []struct {
+		method, url string
+		status      int
+		code        string
+	}{
+		{http.MethodGet, "/field", http.StatusUnprocessableEntity, CodeValidation},
+		{http.MethodGet, "/failure", http.StatusInternalServerError, CodeInternal},
+		{http.MethodGet, "/missing", http.StatusNotFound, CodeNotFound},
+		{http.MethodPost, "/field", http.StatusMethodNotAllowed, CodeMethodNotAllowed},
+	}
+	for _, test := range tests {
+		status, apiErr := serve(t, test.method, test.url)
+		if status != test.status || apiErr.Code != test.code {
+			t.Errorf("%s %s: expected %d %s, got %d %s", test.method, test.url, test.status, test.code, status, apiErr.Code)
+		}
+		if apiErr.Message == "" || apiErr.RequestID != "req-1" {
+			t.Errorf("%s %s: expected a message and the request id, got %+v", test.method, test.url, apiErr)
+		}
+	}
+
+	_, apiErr := serve(t, http.MethodGet, "/field")
+	if len(apiErr.Details) != 1 || apiErr.Details[0] != (Detail{Field: "email", Message: "must be a valid email"}) {
+		t.Errorf("expected the invalid field in the details, got %+v", apiErr.Details)
+	}
+}
+
+func TestServe(t *testing.T) {
+	e := echo.New()
+	handled := 0
+	e.HTTPErrorHandler = func(err error, c echo.Context) {
+		handled++
+		Handler(err, c)
+	}
+	//Two middlewares reading the status, as logging and metrics do
+	var statuses []int
+	record := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			statuses = append(statuses, Serve(c, next))
+			return nil
+		}
+	}
+	e.Use(record, record)
+	e.GET("/conflict", func(c echo.Context) error {
+		return New(http.StatusConflict, CodeIllegalState, "order is shipped")
+	})
+
+	resp := httptest.NewRecorder()
+	e.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/conflict", nil))
+	if resp.Code != http.StatusConflict || handled != 1 {
+		t.Fatalf("expected one 409 answer, got %d answered %d times", resp.Code, handled)
+	}
+	if len(statuses) != 2 || statuses[0] != http.StatusConflict || statuses[1] != http.StatusConflict {
+		t.Fatalf("expected both middlewares to see 409, got %v", statuses)
+	}
+}
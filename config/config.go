@@ -0,0 +1,391 @@
+package config
+
+import (
+	//Inbuild package(s)
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	//Third party package(s)
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+// Settings of the application, loaded once at start-up and passed to every
+// package that needs them
+type Config struct {
+	Server   Server   `yaml:"server"`
+	Database Database `yaml:"database"`
+	JWT      JWT      `yaml:"jwt"`
+	Log      Log      `yaml:"log"`
+	Tracing  Tracing  `yaml:"tracing"`
+}
+
+// HTTP server settings
+type Server struct {
+	Addr         string        `yaml:"addr"`
+	ReadTimeout  time.Duration `yaml:"read_timeout"`
+	WriteTimeout time.Duration `yaml:"write_timeout"`
+	IdleTimeout  time.Duration `yaml:"idle_timeout"`
+
+	//How long in-flight requests may take to finish on shutdown
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+}
+
+// Database settings. For sqlite the database names are file paths, or
+// ':memory:' for an in-memory database.
+type Database struct {
+	Driver   string `yaml:"driver"`
+	Host     string `yaml:"host"`
+	Port     string `yaml:"port"`
+	User     string `yaml:"user"`
+	Password string `yaml:"password"`
+	Name     string `yaml:"name"`
+	TestName string `yaml:"test_name"`
+
+	//TLS of the postgres connection
+	SSLMode     string `yaml:"sslmode"`
+	SSLRootCert string `yaml:"sslrootcert"`
+	SSLCert     string `yaml:"sslcert"`
+	SSLKey      string `yaml:"sslkey"`
+
+	//Connection pool
+	MaxOpenConns    int           `yaml:"max_open_conns"`
+	MaxIdleConns    int           `yaml:"max_idle_conns"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime"`
+	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time"`
+
+	//How long to keep retrying while the database comes up
+	ConnectTimeout time.Duration `yaml:"connect_timeout"`
+}
+
+// Login token settings
+type JWT struct {
+	Secret string        `yaml:"secret"`
+	Expiry time.Duration `yaml:"expiry"`
+}
+
+// Log settings. Output is 'stdout', 'stderr' or a file the JSON lines are
+// appended to, relative to Dir unless absolute.
+type Log struct {
+	Level  string `yaml:"level"`
+	Output string `yaml:"output"`
+	Dir    string `yaml:"dir"`
+
+	//Rotation of the log file, by size and every RotateInterval (0 for
+	//size only), keeping MaxBackups files for MaxAgeDays (0 for no limit)
+	MaxSizeMB      int           `yaml:"max_size_mb"`
+	RotateInterval time.Duration `yaml:"rotate_interval"`
+	MaxBackups     int           `yaml:"max_backups"`
+	MaxAgeDays     int           `yaml:"max_age_days"`
+	Compress       bool          `yaml:"compress"`
+}
+
+// OpenTelemetry tracing settings. Spans are exported over OTLP/HTTP to
+// Endpoint (e.g. http://localhost:4318); nothing is exported when it's empty.
+type Tracing struct {
+	Endpoint    string  `yaml:"endpoint"`
+	ServiceName string  `yaml:"service_name"`
+	SampleRatio float64 `yaml:"sample_ratio"`
+}
+
+// Configuration used when nothing else is set
+func Default() Config {
+	return Config{
+		Server: Server{
+			Addr:         ":8000",
+			ReadTimeout:  15 * time.Second,
+			WriteTimeout: 15 * time.Second,
+			IdleTimeout:  60 * time.Second,
+
+			ShutdownTimeout: 30 * time.Second,
+		},
+		Database: Database{
+			Driver:          "postgres",
+			Host:            "localhost",
+			Port:            "5432",
+			SSLMode:         "disable",
+			MaxOpenConns:    25,
+			MaxIdleConns:    10,
+			ConnMaxLifetime: 30 * time.Minute,
+			ConnMaxIdleTime: 5 * time.Minute,
+			ConnectTimeout:  30 * time.Second,
+		},
+		JWT: JWT{Expiry: 31 * 24 * time.Hour},
+		Log: Log{
+			Level:          "info",
+			Output:         "log.log",
+			Dir:            ".",
+			MaxSizeMB:      100,
+			RotateInterval: 24 * time.Hour,
+			MaxBackups:     7,
+			MaxAgeDays:     30,
+			Compress:       true,
+		},
+		Tracing: Tracing{ServiceName: "online", SampleRatio: 1},
+	}
+}
+
+// Register the configuration flags on flags
+func RegisterFlags(flags *flag.FlagSet) {
+	flags.String("config", "", "YAML configuration file (env CONFIG_FILE)")
+	flags.String("addr", "", "address the server listens on, e.g. ':8000' (env LISTEN_ADDR)")
+	flags.String("db-driver", "", "database driver: postgres or sqlite (env DB_DRIVER)")
+	flags.String("db-name", "", "database name, or file for sqlite (env DB_NAME)")
+	flags.String("log-level", "", "log level: debug, info, warn or error (env LOG_LEVEL)")
+}
+
+// Load the configuration without validating it, from the parsed flags
+// registered by RegisterFlags. Later sources override earlier ones: defaults,
+// the YAML file given by '-config' (or 'CONFIG_FILE'), the '.env' file of the
+// working directory, the environment and the flags given on the command line.
+func Parse(flags *flag.FlagSet) (Config, error) {
+	given := map[string]string{}
+	flags.Visit(func(f *flag.Flag) { given[f.Name] = f.Value.String() })
+
+	cfg := Default()
+	dotenv, err := godotenv.Read(".env")
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return cfg, fmt.Errorf("read .env: %w", err)
+	}
+	env := func(key string) (string, bool) {
+		if value, ok := os.LookupEnv(key); ok {
+			return value, true
+		}
+		value, ok := dotenv[key]
+		return value, ok
+	}
+
+	file, ok := given["config"]
+	if !ok {
+		file, _ = env("CONFIG_FILE")
+	}
+	if file != "" {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return cfg, err
+		}
+		if err := yaml.Unmarshal(content, &cfg); err != nil {
+			return cfg, fmt.Errorf("parse %s: %w", file, err)
+		}
+	}
+	if err := cfg.loadEnv(env, dotenv); err != nil {
+		return cfg, err
+	}
+
+	//Only the flags given on the command line override the other sources
+	override := func(name string, value *string) {
+		if v, ok := given[name]; ok {
+			*value = v
+		}
+	}
+	override("addr", &cfg.Server.Addr)
+	override("db-driver", &cfg.Database.Driver)
+	override("db-name", &cfg.Database.Name)
+	override("log-level", &cfg.Log.Level)
+	cfg.Database.Driver = strings.ToLower(cfg.Database.Driver)
+	cfg.Log.Level = strings.ToLower(cfg.Log.Level)
+	return cfg, nil
+}
+
+// Load the configuration and validate it
+func Load(flags *flag.FlagSet) (Config, error) {
+	cfg, err := Parse(flags)
+	if err != nil {
+		return cfg, err
+	}
+	return cfg, cfg.Validate()
+}
+
+// Override the settings with the environment variables that are set, read
+// by env from the environment or else from the '.env' content dotenv
+func (cfg *Config) loadEnv(env func(string) (string, bool), dotenv map[string]string) error {
+	var errs []error
+	text := func(key string, value *string) {
+		if v, ok := env(key); ok {
+			*value = v
+		}
+	}
+	//The names used before the config package are read when the new one
+	//isn't set, so the existing deployments keep their settings. The generic
+	//ones only from '.env', as the shell or the platform set their own USER,
+	//HOST or PORT.
+	fromDotenv := func(key string) (string, bool) {
+		v, ok := dotenv[key]
+		return v, ok
+	}
+	renamed := func(key string, value *string, oldKey string, lookup func(string) (string, bool)) {
+		if _, ok := env(key); ok {
+			text(key, value)
+		} else if v, ok := lookup(oldKey); ok {
+			*value = v
+		}
+	}
+	number := func(key string, value *int) {
+		if v, ok := env(key); ok {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: invalid number %q", key, v))
+				return
+			}
+			*value = n
+		}
+	}
+	boolean := func(key string, value *bool) {
+		if v, ok := env(key); ok {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: invalid boolean %q", key, v))
+				return
+			}
+			*value = b
+		}
+	}
+	ratio := func(key string, value *float64) {
+		if v, ok := env(key); ok {
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: invalid number %q", key, v))
+				return
+			}
+			*value = f
+		}
+	}
+	duration := func(key string, value *time.Duration) {
+		if v, ok := env(key); ok {
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: invalid duration %q", key, v))
+				return
+			}
+			*value = d
+		}
+	}
+	text("LISTEN_ADDR", &cfg.Server.Addr)
+	duration("READ_TIMEOUT", &cfg.Server.ReadTimeout)
+	duration("WRITE_TIMEOUT", &cfg.Server.WriteTimeout)
+	duration("IDLE_TIMEOUT", &cfg.Server.IdleTimeout)
+	duration("SHUTDOWN_TIMEOUT", &cfg.Server.ShutdownTimeout)
+	text("DB_DRIVER", &cfg.Database.Driver)
+	renamed("DB_HOST", &cfg.Database.Host, "HOST", fromDotenv)
+	renamed("DB_PORT", &cfg.Database.Port, "PORT", fromDotenv)
+	renamed("DB_USER", &cfg.Database.User, "USER", fromDotenv)
+	renamed("DB_PASSWORD", &cfg.Database.Password, "PASSWORD", fromDotenv)
+	renamed("DB_NAME", &cfg.Database.Name, "DBNAME", env)
+	text("TEST_DBNAME", &cfg.Database.TestName)
+	text("DB_SSLMODE", &cfg.Database.SSLMode)
+	text("DB_SSLROOTCERT", &cfg.Database.SSLRootCert)
+	text("DB_SSLCERT", &cfg.Database.SSLCert)
+	text("DB_SSLKEY", &cfg.Database.SSLKey)
+	number("DB_MAX_OPEN_CONNS", &cfg.Database.MaxOpenConns)
+	number("DB_MAX_IDLE_CONNS", &cfg.Database.MaxIdleConns)
+	duration("DB_CONN_MAX_LIFETIME", &cfg.Database.ConnMaxLifetime)
+	duration("DB_CONN_MAX_IDLE_TIME", &cfg.Database.ConnMaxIdleTime)
+	duration("DB_CONNECT_TIMEOUT", &cfg.Database.ConnectTimeout)
+	renamed("JWT_SECRET", &cfg.JWT.Secret, "SECRET_KEY", env)
+	duration("JWT_EXPIRY", &cfg.JWT.Expiry)
+	text("LOG_LEVEL", &cfg.Log.Level)
+	text("LOG_OUTPUT", &cfg.Log.Output)
+	text("LOG_DIR", &cfg.Log.Dir)
+	number("LOG_MAX_SIZE_MB", &cfg.Log.MaxSizeMB)
+	duration("LOG_ROTATE_INTERVAL", &cfg.Log.RotateInterval)
+	number("LOG_MAX_BACKUPS", &cfg.Log.MaxBackups)
+	number("LOG_MAX_AGE_DAYS", &cfg.Log.MaxAgeDays)
+	boolean("LOG_COMPRESS", &cfg.Log.Compress)
+	text("OTEL_EXPORTER_OTLP_ENDPOINT", &cfg.Tracing.Endpoint)
+	text("OTEL_SERVICE_NAME", &cfg.Tracing.ServiceName)
+	ratio("OTEL_TRACES_SAMPLER_ARG", &cfg.Tracing.SampleRatio)
+	return errors.Join(errs...)
+}
+
+// Report every invalid setting at once
+func (cfg Config) Validate() error {
+	var errs []error
+	if _, port, err := net.SplitHostPort(cfg.Server.Addr); err != nil {
+		errs = append(errs, fmt.Errorf("server address %q: %w", cfg.Server.Addr, err))
+	} else if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+		errs = append(errs, fmt.Errorf("server address %q: invalid port", cfg.Server.Addr))
+	}
+	if cfg.Server.ReadTimeout < 0 || cfg.Server.WriteTimeout < 0 || cfg.Server.IdleTimeout < 0 || cfg.Server.ShutdownTimeout < 0 {
+		errs = append(errs, errors.New("server timeouts must not be negative"))
+	}
+	if err := cfg.Database.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if cfg.JWT.Secret == "" {
+		errs = append(errs, errors.New("JWT_SECRET is required"))
+	}
+	if cfg.JWT.Expiry <= 0 {
+		errs = append(errs, errors.New("JWT expiry must be positive"))
+	}
+	switch cfg.Log.Level {
+	case "debug", "info", "warn", "error":
+	default:
+		errs = append(errs, fmt.Errorf("log level %q: expected debug, info, warn or error", cfg.Log.Level))
+	}
+	if cfg.Log.Output == "" {
+		errs = append(errs, errors.New("LOG_OUTPUT is required"))
+	}
+	if cfg.Log.MaxSizeMB <= 0 {
+		errs = append(errs, errors.New("LOG_MAX_SIZE_MB must be positive"))
+	}
+	if cfg.Log.RotateInterval < 0 || cfg.Log.MaxBackups < 0 || cfg.Log.MaxAgeDays < 0 {
+		errs = append(errs, errors.New("LOG_ROTATE_INTERVAL, LOG_MAX_BACKUPS and LOG_MAX_AGE_DAYS must not be negative"))
+	}
+	if cfg.Tracing.Endpoint != "" {
+		if u, err := url.Parse(cfg.Tracing.Endpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, fmt.Errorf("OTEL_EXPORTER_OTLP_ENDPOINT %q: expected an http(s) URL", cfg.Tracing.Endpoint))
+		}
+	}
+	if cfg.Tracing.SampleRatio < 0 || cfg.Tracing.SampleRatio > 1 {
+		errs = append(errs, fmt.Errorf("OTEL_TRACES_SAMPLER_ARG %v: expected a ratio between 0 and 1", cfg.Tracing.SampleRatio))
+	}
+	return errors.Join(errs...)
+}
+
+// Validate the database settings, the only ones the migrate and seed
+// commands need
+func (db Database) Validate() error {
+	var errs []error
+	switch db.Driver {
+	case "postgres":
+		if db.Host == "" {
+			errs = append(errs, errors.New("DB_HOST is required for postgres"))
+		}
+		if _, err := strconv.ParseUint(db.Port, 10, 16); err != nil {
+			errs = append(errs, fmt.Errorf("DB_PORT %q: invalid port", db.Port))
+		}
+		if db.User == "" {
+			errs = append(errs, errors.New("DB_USER is required for postgres"))
+		}
+		switch db.SSLMode {
+		case "disable", "allow", "prefer", "require", "verify-ca", "verify-full":
+		default:
+			errs = append(errs, fmt.Errorf("DB_SSLMODE %q: expected disable, allow, prefer, require, verify-ca or verify-full", db.SSLMode))
+		}
+		if (db.SSLCert == "") != (db.SSLKey == "") {
+			errs = append(errs, errors.New("DB_SSLCERT and DB_SSLKEY must be set together"))
+		}
+	case "sqlite", "sqlite3":
+	default:
+		errs = append(errs, fmt.Errorf("database driver %q: expected postgres or sqlite", db.Driver))
+	}
+	if db.Name == "" {
+		errs = append(errs, errors.New("DB_NAME is required"))
+	}
+	if db.MaxOpenConns < 0 || db.MaxIdleConns < 0 {
+		errs = append(errs, errors.New("DB_MAX_OPEN_CONNS and DB_MAX_IDLE_CONNS must not be negative"))
+	} else if db.MaxOpenConns > 0 && db.MaxIdleConns > db.MaxOpenConns {
+		errs = append(errs, fmt.Errorf("DB_MAX_IDLE_CONNS (%d) must not exceed DB_MAX_OPEN_CONNS (%d)", db.MaxIdleConns, db.MaxOpenConns))
+	}
+	if db.ConnMaxLifetime < 0 || db.ConnMaxIdleTime < 0 || db.ConnectTimeout < 0 {
+		errs = append(errs, errors.New("database connection durations must not be negative"))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,136 @@
+package config
+
+import (
+	//Inbuild package(s)
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Parse args with the configuration flags, in a directory holding the given
+// '.env' content (none when empty)
+func parse(t *testing.T, dotenv string, args ...string) (Config, error) {
+	t.Helper()
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmp := t.TempDir()
+	if dotenv != "" {
+		os.WriteFile(filepath.Join(tmp, ".env"), []byte(dotenv), 0644)
+	}
+	os.Chdir(tmp)
+	t.Cleanup(func() { os.Chdir(dir) })
+
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	RegisterFlags(flags)
+	if err := flags.Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	return Load(flags)
+}
+
+func TestPrecedence(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "config.yaml")
+	os.WriteFile(file, []byte("server:\n  addr: ':7000'\n  read_timeout: 3s\ndatabase:\n  driver: sqlite\n  name: file.db\njwt:\n  secret: from-file\n"), 0644)
+	t.Setenv("CONFIG_FILE", file)
+	t.Setenv("JWT_SECRET", "from-env")
+
+	cfg, err := parse(t, "JWT_SECRET=from-dotenv\nLOG_LEVEL=error\nDB_NAME=dotenv.db\n", "-db-name", "flag.db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Server.Addr != ":7000" || cfg.Server.ReadTimeout != 3*time.Second {
+		t.Errorf("expected the server settings of the file, got %+v", cfg.Server)
+	}
+	if cfg.JWT.Secret != "from-env" {
+		t.Errorf("expected the environment to override .env and the file, got %q", cfg.JWT.Secret)
+	}
+	if cfg.Log.Level != "error" {
+		t.Errorf("expected the log level of .env, got %q", cfg.Log.Level)
+	}
+	if cfg.Database.Name != "flag.db" {
+		t.Errorf("expected the flag to override every other source, got %q", cfg.Database.Name)
+	}
+	if cfg.Server.WriteTimeout != Default().Server.WriteTimeout || cfg.JWT.Expiry != Default().JWT.Expiry {
+		t.Errorf("expected defaults for the settings that aren't set, got %+v", cfg)
+	}
+}
+
+func TestOldNames(t *testing.T) {
+	//The .env of the existing deployments
+	dotenv := "SECRET_KEY = old-secret\nHOST = db.local\nPORT = 5435\nUSER = postgres\nPASSWORD = password\nDBNAME = online_purchase\n"
+
+	t.Run("From .env", func(t *testing.T) {
+		//Set by the shell and the platform, not meant for the database
+		t.Setenv("USER", "alice")
+		t.Setenv("PORT", "8080")
+		t.Setenv("HOST", "laptop")
+		cfg, err := parse(t, dotenv)
+		if err != nil {
+			t.Fatal(err)
+		}
+		db := cfg.Database
+		if cfg.JWT.Secret != "old-secret" || db.Host != "db.local" || db.Port != "5435" || db.User != "postgres" || db.Password != "password" || db.Name != "online_purchase" {
+			t.Errorf("expected the settings of the old names of .env, got %+v %+v", cfg.JWT, db)
+		}
+	})
+
+	t.Run("New names first", func(t *testing.T) {
+		t.Setenv("DB_PORT", "5436")
+		cfg, err := parse(t, dotenv+"DB_USER = online\n")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cfg.Database.Port != "5436" || cfg.Database.User != "online" {
+			t.Errorf("expected the new names to win over the old ones, got %+v", cfg.Database)
+		}
+	})
+
+	t.Run("Generic names of the environment ignored", func(t *testing.T) {
+		t.Setenv("USER", "alice")
+		_, err := parse(t, "JWT_SECRET=secret\nDB_NAME=online_purchase\n")
+		if err == nil || !strings.Contains(err.Error(), "DB_USER is required") {
+			t.Fatalf("expected DB_USER to be required, got %v", err)
+		}
+	})
+
+	t.Run("Specific names of the environment", func(t *testing.T) {
+		t.Setenv("SECRET_KEY", "env-secret")
+		t.Setenv("DBNAME", "env_db")
+		cfg, err := parse(t, "DB_USER=postgres\n")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cfg.JWT.Secret != "env-secret" || cfg.Database.Name != "env_db" {
+			t.Errorf("expected SECRET_KEY and DBNAME of the environment, got %+v %+v", cfg.JWT, cfg.Database)
+		}
+	})
+}
+
+func TestValidate(t *testing.T) {
+	t.Setenv("LISTEN_ADDR", "8000")
+	t.Setenv("DB_DRIVER", "mysql")
+	t.Setenv("LOG_LEVEL", "verbose")
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "collector:4318")
+	t.Setenv("OTEL_TRACES_SAMPLER_ARG", "2")
+	_, err := parse(t, "")
+	if err == nil {
+		t.Fatal("expected an invalid configuration")
+	}
+	for _, problem := range []string{"server address", "mysql", "DB_NAME", "JWT_SECRET", "verbose", "collector:4318", "OTEL_TRACES_SAMPLER_ARG"} {
+		if !strings.Contains(err.Error(), problem) {
+			t.Errorf("expected %q to be reported, got:\n%s", problem, err)
+		}
+	}
+}
+
+func TestInvalidDuration(t *testing.T) {
+	t.Setenv("JWT_EXPIRY", "31 days")
+	if _, err := parse(t, ""); err == nil || !strings.Contains(err.Error(), "JWT_EXPIRY") {
+		t.Fatalf("expected an invalid JWT_EXPIRY, got %v", err)
+	}
+}
@@ -0,0 +1,12 @@
+package dbUpdates
+
+import (
+	//Inbuild package(s)
+	"embed"
+)
+
+// Versioned SQL migrations, one directory per database dialect.
+// Files are named '<version>_<name>.up.sql' and '<version>_<name>.down.sql'.
+//
+//go:embed postgres/*.sql sqlite/*.sql
+var Files embed.FS
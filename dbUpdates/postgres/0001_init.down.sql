@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS order_statuses;
+DROP TABLE IF EXISTS order_product_infos;
+DROP TABLE IF EXISTS product_infos;
+DROP TABLE IF EXISTS authentications;
+DROP TABLE IF EXISTS users;
+DROP TABLE IF EXISTS roles;
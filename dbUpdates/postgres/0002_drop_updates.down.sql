@@ -0,0 +1,4 @@
+CREATE TABLE IF NOT EXISTS updates (
+	id        bigserial PRIMARY KEY,
+	file_name text
+);
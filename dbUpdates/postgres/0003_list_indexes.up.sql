@@ -0,0 +1,7 @@
+-- Keyset pagination of the lists: each index holds a filter or the owner of
+-- the rows, then the id the pages are ordered by
+CREATE INDEX IF NOT EXISTS idx_product_infos_brand_name ON product_infos (brand_name, product_id);
+CREATE INDEX IF NOT EXISTS idx_order_product_infos_user_id ON order_product_infos (user_id, order_id);
+CREATE INDEX IF NOT EXISTS idx_order_product_infos_payment_status ON order_product_infos (payment_status, order_id);
+CREATE INDEX IF NOT EXISTS idx_order_statuses_order_id ON order_statuses (order_id);
+CREATE INDEX IF NOT EXISTS idx_order_statuses_order_status ON order_statuses (order_status, order_id);
@@ -0,0 +1,3 @@
+-- Full-text search of the products, on the same document as repository.SearchProducts
+CREATE INDEX IF NOT EXISTS idx_product_infos_search ON product_infos
+	USING GIN (to_tsvector('simple', coalesce(brand_name, '') || ' ' || coalesce(ram_capacity, '')));
@@ -0,0 +1,38 @@
+CREATE TABLE IF NOT EXISTS order_statuses (
+	order_id       bigint REFERENCES order_product_infos(order_id),
+	user_id        bigint REFERENCES users(user_id),
+	payment_status varchar(50) DEFAULT 'pending',
+	order_status   varchar(50) DEFAULT 'waiting for payment',
+	created_at     timestamptz,
+	updated_at     timestamptz,
+	cancelled_at   timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_order_statuses_cancelled_at ON order_statuses (cancelled_at);
+CREATE INDEX IF NOT EXISTS idx_order_statuses_order_id ON order_statuses (order_id);
+CREATE INDEX IF NOT EXISTS idx_order_statuses_order_status ON order_statuses (order_status, order_id);
+
+INSERT INTO order_statuses (order_id, user_id, payment_status, order_status, created_at, updated_at, cancelled_at)
+SELECT order_id, user_id,
+	CASE status
+		WHEN 'pending_payment' THEN 'pending'
+		WHEN 'cancelled' THEN 'Refunded'
+		WHEN 'refunded' THEN 'Refunded'
+		ELSE 'paid'
+	END,
+	CASE status
+		WHEN 'pending_payment' THEN 'waiting for payment'
+		WHEN 'paid' THEN 'order confirmed'
+		WHEN 'refunded' THEN 'cancelled'
+		ELSE status
+	END,
+	created_at, updated_at, cancelled_at
+FROM order_product_infos;
+UPDATE order_product_infos SET payment_status = CASE status
+	WHEN 'pending_payment' THEN 'pending'
+	WHEN 'cancelled' THEN 'Refunded'
+	WHEN 'refunded' THEN 'Refunded'
+	ELSE 'Paid'
+END;
+
+DROP INDEX IF EXISTS idx_order_product_infos_status;
+ALTER TABLE order_product_infos DROP COLUMN IF EXISTS status;
@@ -0,0 +1,24 @@
+-- The state of an order moves into the order (models.OrderState), replacing
+-- the payment and free-text statuses of order_statuses
+ALTER TABLE order_product_infos ADD COLUMN IF NOT EXISTS status varchar(50) DEFAULT 'pending_payment';
+
+-- Paid orders keep the known steps of their old status. The cancelled orders
+-- were all marked 'Refunded', paid or not, so they stay refunded.
+UPDATE order_product_infos SET status = CASE
+	WHEN payment_status = 'Refunded' THEN 'refunded'
+	WHEN lower(payment_status) = 'paid' THEN coalesce((
+		SELECT order_status FROM order_statuses
+		WHERE order_statuses.order_id = order_product_infos.order_id
+			AND order_status IN ('packed', 'shipped', 'delivered', 'returned')
+		LIMIT 1), 'paid')
+	ELSE 'pending_payment'
+END;
+UPDATE order_product_infos SET payment_status = CASE status
+	WHEN 'pending_payment' THEN 'pending'
+	WHEN 'cancelled' THEN 'none'
+	WHEN 'refunded' THEN 'refunded'
+	ELSE 'paid'
+END;
+
+DROP TABLE IF EXISTS order_statuses;
+CREATE INDEX IF NOT EXISTS idx_order_product_infos_status ON order_product_infos (status, order_id);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_order_events_order_id;
+DROP TABLE IF EXISTS order_events;
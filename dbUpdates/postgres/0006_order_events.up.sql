@@ -0,0 +1,22 @@
+-- Timeline of the orders (models.OrderEvent), appended to by every change of
+-- their state and never updated
+CREATE TABLE IF NOT EXISTS order_events (
+	event_id    bigserial PRIMARY KEY,
+	order_id    bigint REFERENCES order_product_infos(order_id),
+	from_status varchar(50),
+	to_status   varchar(50),
+	actor_id    bigint,
+	actor_role  varchar(50),
+	note        varchar(500),
+	created_at  timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_order_events_order_id ON order_events (order_id, event_id);
+
+-- The orders placed before start with their placement and, when they moved
+-- on, their current state, the steps in between being unknown
+INSERT INTO order_events (order_id, from_status, to_status, actor_id, actor_role, note, created_at)
+SELECT order_id, '', 'pending_payment', user_id, 'user', 'order placed', created_at
+FROM order_product_infos;
+INSERT INTO order_events (order_id, from_status, to_status, actor_id, actor_role, note, created_at)
+SELECT order_id, '', status, 0, 'system', 'state before the timeline', updated_at
+FROM order_product_infos WHERE status <> 'pending_payment';
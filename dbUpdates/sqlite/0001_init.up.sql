@@ -0,0 +1,57 @@
+CREATE TABLE IF NOT EXISTS roles (
+	role_id bigint PRIMARY KEY,
+	role    varchar(50)
+);
+
+CREATE TABLE IF NOT EXISTS users (
+	user_id  integer PRIMARY KEY AUTOINCREMENT,
+	username varchar(100),
+	email    varchar(100) UNIQUE,
+	password varchar(100),
+	role_id  bigint REFERENCES roles(role_id)
+);
+
+CREATE TABLE IF NOT EXISTS authentications (
+	user_id bigint PRIMARY KEY,
+	token   varchar(200)
+);
+
+CREATE TABLE IF NOT EXISTS product_infos (
+	product_id    integer PRIMARY KEY AUTOINCREMENT,
+	brand_name    varchar(100),
+	product_price text,
+	ram_capacity  varchar(100),
+	ram_price     text
+);
+
+CREATE TABLE IF NOT EXISTS order_product_infos (
+	order_id       integer PRIMARY KEY AUTOINCREMENT,
+	user_id        bigint REFERENCES users(user_id),
+	brand_name     varchar(100),
+	product_price  text,
+	ram_capacity   varchar(100),
+	ram_price      text,
+	dvd_rw_drive   numeric,
+	name           varchar(50),
+	address        varchar(200),
+	phone_number   varchar(200),
+	total_price    text,
+	payment_status varchar(50) DEFAULT 'pending',
+	created_at     datetime,
+	updated_at     datetime,
+	cancelled_at   datetime
+);
+CREATE INDEX IF NOT EXISTS idx_order_product_infos_cancelled_at ON order_product_infos (cancelled_at);
+
+CREATE TABLE IF NOT EXISTS order_statuses (
+	order_id       bigint REFERENCES order_product_infos(order_id),
+	user_id        bigint REFERENCES users(user_id),
+	payment_status varchar(50) DEFAULT 'pending',
+	order_status   varchar(50) DEFAULT 'waiting for payment',
+	created_at     datetime,
+	updated_at     datetime,
+	cancelled_at   datetime
+);
+CREATE INDEX IF NOT EXISTS idx_order_statuses_cancelled_at ON order_statuses (cancelled_at);
+
+INSERT INTO roles (role_id, role) VALUES (1, 'admin'), (2, 'user') ON CONFLICT DO NOTHING;
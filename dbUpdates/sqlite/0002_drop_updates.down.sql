@@ -0,0 +1,4 @@
+CREATE TABLE IF NOT EXISTS updates (
+	id        integer PRIMARY KEY AUTOINCREMENT,
+	file_name text
+);
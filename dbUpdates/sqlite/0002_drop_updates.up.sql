@@ -0,0 +1,2 @@
+-- Bookkeeping table of the old reflection based runner, replaced by schema_migrations
+DROP TABLE IF EXISTS updates;
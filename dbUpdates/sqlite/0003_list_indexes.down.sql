@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_order_statuses_order_status;
+DROP INDEX IF EXISTS idx_order_statuses_order_id;
+DROP INDEX IF EXISTS idx_order_product_infos_payment_status;
+DROP INDEX IF EXISTS idx_order_product_infos_user_id;
+DROP INDEX IF EXISTS idx_product_infos_brand_name;
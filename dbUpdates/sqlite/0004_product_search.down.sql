@@ -0,0 +1 @@
+-- 0004_product_search (down) for sqlite
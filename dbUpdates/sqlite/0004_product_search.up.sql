@@ -0,0 +1,2 @@
+-- 0004_product_search (up) for sqlite
+-- Nothing to do: the products are searched with LIKE, without full-text index
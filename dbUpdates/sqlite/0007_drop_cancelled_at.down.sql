@@ -0,0 +1,3 @@
+ALTER TABLE order_product_infos ADD COLUMN cancelled_at datetime;
+UPDATE order_product_infos SET cancelled_at = updated_at WHERE status IN ('cancelled', 'refunded');
+CREATE INDEX IF NOT EXISTS idx_order_product_infos_cancelled_at ON order_product_infos (cancelled_at);
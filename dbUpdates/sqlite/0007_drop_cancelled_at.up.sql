@@ -0,0 +1,4 @@
+-- The state of an order tells if it was cancelled or refunded, the closed
+-- orders are no longer soft deleted
+DROP INDEX IF EXISTS idx_order_product_infos_cancelled_at;
+ALTER TABLE order_product_infos DROP COLUMN cancelled_at;
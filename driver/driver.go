@@ -1,78 +1,132 @@
-package driver
-
-import (
-	//user defined packages
-	"online/helper"
-	"online/logs"
-
-	//Inbuild packages
-	"fmt"
-	"os"
-
-	//Third-party packages
-	"gorm.io/driver/postgres"
-	"gorm.io/gorm"
-)
-
-func DbConnection() *gorm.DB {
-	log := logs.Log()
-
-	//Loading a '.env' file
-	if err := helper.Config(`C:\Jackupsurya\GolangTasks\Real-Time-Tasks\RTE_Jackup\.env`); err != nil {
-		log.Error.Println("Error : 'Error at loading '.env' file'")
-	}
-	Host := os.Getenv("HOST")
-	Port := os.Getenv("PORT")
-	User := os.Getenv("USER")
-	Password := os.Getenv("PASSWORD")
-	Dbname := os.Getenv("DBNAME")
-
-	//create a connection to database
-	psqlInfo := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable", Host, Port, User, Password, Dbname)
-	Db, err := gorm.Open(postgres.Open(psqlInfo), &gorm.Config{})
-	if err != nil {
-		DbConnection, err := Db.DB()
-		if err != nil {
-			log.Error.Println("Error : 'Invalid Database connection' ", err)
-		}
-		defer func() {
-			fmt.Println("Database closed")
-			DbConnection.Close()
-		}()
-		panic(err)
-	}
-	log.Info.Printf("Message : 'Established a successful connection to %s database!!!'\n", Dbname)
-	return Db
-}
-
-func TestDbConnection() *gorm.DB {
-	log := logs.Log()
-
-	//Loading a '.env' file
-	if err := helper.Config(`C:\Jackupsurya\GolangTasks\Real-Time-Tasks\RTE_Jackup\.env`); err != nil {
-		log.Error.Println("Error : 'Error at loading '.env' file'")
-	}
-
-	Host := os.Getenv("HOST")
-	Port := os.Getenv("PORT")
-	User := os.Getenv("USER")
-	Password := os.Getenv("PASSWORD")
-	Dbname := os.Getenv("TEST_DBNAME")
-
-	// create a connection to database
-	psqlInfo := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable", Host, Port, User, Password, Dbname)
-	Db, err := gorm.Open(postgres.Open(psqlInfo), &gorm.Config{})
-	if err != nil {
-		DbConnection, err := Db.DB()
-		if err != nil {
-			log.Error.Println("Error : 'Invalid Database connection' ", err)
-		}
-		defer func() {
-			fmt.Println("Database closed")
-			DbConnection.Close()
-		}()
-		panic(err)
-	}
-	log.Info.Printf("Message : 'Established a successful connection to %s database!!!'\n", Dbname)
-	return Db
-}
+package driver
+
+import (
+	//user defined packages
+	"online/config"
+	"online/logs"
+
+	//Inbuild packages
+	"fmt"
+	"strings"
+	"time"
+
+	//Third-party packages
+	"github.com/glebarez/sqlite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Select the database backend of the configuration. For sqlite the database
+// name is the file path, or ':memory:' for an in-memory database shared by
+// every connection of this process.
+func dialector(cfg config.Database, Dbname string) gorm.Dialector {
+	switch cfg.Driver {
+	case "sqlite", "sqlite3":
+		if Dbname == ":memory:" {
+			return sqlite.Open("file::memory:?cache=shared")
+		}
+		//wait for a busy database file instead of failing with 'database is locked'
+		if !strings.Contains(Dbname, "?") {
+			Dbname += "?_pragma=busy_timeout(5000)"
+		}
+		return sqlite.Open(Dbname)
+	}
+	settings := []string{
+		"host=" + quote(cfg.Host),
+		"port=" + quote(cfg.Port),
+		"user=" + quote(cfg.User),
+		"password=" + quote(cfg.Password),
+		"dbname=" + quote(Dbname),
+		"sslmode=" + quote(cfg.SSLMode),
+	}
+	for _, file := range [][2]string{{"sslrootcert", cfg.SSLRootCert}, {"sslcert", cfg.SSLCert}, {"sslkey", cfg.SSLKey}} {
+		if file[1] != "" {
+			settings = append(settings, file[0]+"="+quote(file[1]))
+		}
+	}
+	return postgres.Open(strings.Join(settings, " "))
+}
+
+// Quote a value of a postgres connection string, so passwords and paths
+// may contain spaces and quotes
+func quote(value string) string {
+	value = strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value)
+	return "'" + value + "'"
+}
+
+// Connect to the main database of the configuration
+func DbConnection(cfg config.Database) (*gorm.DB, error) {
+	return Connection(cfg, cfg.Name)
+}
+
+// Connect to the test database of the configuration
+func TestDbConnection(cfg config.Database) (*gorm.DB, error) {
+	return Connection(cfg, cfg.TestName)
+}
+
+// Connect to the named database of the configured server and set up its
+// connection pool. While the database is not reachable (e.g. postgres is
+// still starting) the connection is retried with backoff for up to the
+// configured connect timeout.
+func Connection(cfg config.Database, Dbname string) (*gorm.DB, error) {
+	log := logs.Log()
+	deadline := time.Now().Add(cfg.ConnectTimeout)
+	wait := 250 * time.Millisecond
+	for attempt := 1; ; attempt++ {
+		//create a connection to database, gorm pings it
+		Db, err := gorm.Open(dialector(cfg, Dbname), &gorm.Config{})
+		if err == nil {
+			if err = configurePool(Db, cfg, Dbname); err == nil {
+				log.Info("Established a successful connection to database", "database", Dbname)
+				return Db, nil
+			}
+		}
+		Close(Db)
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			log.Error("Unable to connect to database", "database", Dbname, "attempts", attempt, "error", err)
+			return nil, fmt.Errorf("connect to %s database: %w", Dbname, err)
+		}
+		if wait > remaining {
+			wait = remaining
+		}
+		log.Warn("Database is not ready, retrying", "database", Dbname, "retry_in", wait.String(), "error", err)
+		time.Sleep(wait)
+		if wait *= 2; wait > 5*time.Second {
+			wait = 5 * time.Second
+		}
+	}
+}
+
+// Apply the pool settings of the configuration
+func configurePool(Db *gorm.DB, cfg config.Database, Dbname string) error {
+	sqlDb, err := Db.DB()
+	if err != nil {
+		return err
+	}
+	sqlDb.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqlDb.SetMaxIdleConns(cfg.MaxIdleConns)
+
+	//An in-memory sqlite database is gone once its last connection closes
+	if Dbname == ":memory:" {
+		if cfg.MaxIdleConns < 1 {
+			sqlDb.SetMaxIdleConns(1)
+		}
+		return nil
+	}
+	sqlDb.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	sqlDb.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+	return nil
+}
+
+// Close the connection pool of the database
+func Close(Db *gorm.DB) error {
+	if Db == nil || Db.Config == nil || Db.ConnPool == nil {
+		return nil
+	}
+	sqlDb, err := Db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDb.Close()
+}
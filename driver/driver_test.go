@@ -0,0 +1,58 @@
+package driver
+
+import (
+	//User defined package(s)
+	"online/config"
+
+	//Inbuild package(s)
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestQuote(t *testing.T) {
+	for value, expected := range map[string]string{
+		"secret":      `'secret'`,
+		"":            `''`,
+		"with space":  `'with space'`,
+		`it's \ here`: `'it\'s \\ here'`,
+	} {
+		if quoted := quote(value); quoted != expected {
+			t.Errorf("quote(%q) = %s, expected %s", value, quoted, expected)
+		}
+	}
+}
+
+func TestConnectionRetries(t *testing.T) {
+	cfg := config.Default().Database
+	cfg.Host, cfg.Port, cfg.User = "127.0.0.1", "1", "nobody"
+	cfg.ConnectTimeout = 600 * time.Millisecond
+
+	start := time.Now()
+	Db, err := Connection(cfg, "unreachable")
+	if err == nil || Db != nil {
+		t.Fatal("expected an error for an unreachable database")
+	}
+	if elapsed := time.Since(start); elapsed < cfg.ConnectTimeout || elapsed > cfg.ConnectTimeout+2*time.Second {
+		t.Fatalf("expected retries for about %s, gave up after %s", cfg.ConnectTimeout, elapsed)
+	}
+	if !strings.Contains(err.Error(), "unreachable") {
+		t.Fatalf("expected the database name in the error, got %v", err)
+	}
+}
+
+func TestMemoryPoolKeepsDatabase(t *testing.T) {
+	cfg := config.Default().Database
+	cfg.Driver, cfg.MaxIdleConns = "sqlite", 0
+	Db, err := Connection(cfg, ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer Close(Db)
+	if err := Db.Exec("CREATE TABLE kept (id integer)").Error; err != nil {
+		t.Fatal(err)
+	}
+	if !Db.Migrator().HasTable("kept") {
+		t.Fatal("in-memory database was dropped with its idle connection")
+	}
+}
@@ -0,0 +1,214 @@
+package dto
+
+import (
+	//user defined package(s)
+	"online/apierror"
+	"online/models"
+	"online/repository"
+)
+
+// Request bodies of the API. The 'validate' tags are checked by c.Validate,
+// which reports every invalid field at once.
+
+// Signup credentials
+type SignupReq struct {
+	Username string `json:"username" validate:"required"`
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required,min=8"`
+	Role     string `json:"role" validate:"required,oneof=admin user"`
+}
+
+// Login credentials
+type LoginReq struct {
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required"`
+}
+
+// Details of a new product
+type ProductReq struct {
+	BrandName    string `json:"brand_name" validate:"required"`
+	ProductPrice string `json:"product_price" validate:"required,price"`
+	RamCapacity  string `json:"ram_capacity" validate:"required"`
+	RamPrice     string `json:"ram_price" validate:"required,price"`
+}
+
+// Details of a product to change, the others are left as they are
+type ProductUpdateReq struct {
+	BrandName    string `json:"brand_name"`
+	ProductPrice string `json:"product_price" validate:"omitempty,price"`
+	RamCapacity  string `json:"ram_capacity"`
+	RamPrice     string `json:"ram_price" validate:"omitempty,price"`
+}
+
+// Product ordered and delivery details. The phone number is either 10
+// digits or in the E.164 format (+919876543210).
+type OrderReq struct {
+	BrandName    string `json:"brand_name" validate:"required"`
+	ProductPrice string `json:"product_price" validate:"required,price"`
+	RamCapacity  string `json:"ram_capacity" validate:"required"`
+	RamPrice     string `json:"ram_price" validate:"required,price"`
+	DvdRwDrive   bool   `json:"dvd_rw_drive"`
+	Name         string `json:"name" validate:"required"`
+	Address      string `json:"address" validate:"required"`
+	PhoneNumber  string `json:"phone_number" validate:"required,numeric,e164|len=10"`
+}
+
+// Payment of an order
+type PaymentReq struct {
+	Payment string `json:"payment" validate:"required,price"`
+}
+
+// Next state of an order. Paying and cancelling have their own requests.
+type OrderStatusReq struct {
+	OrderStatus string `json:"order_status" validate:"required,oneof=packed shipped delivered returned refunded"`
+	//Kept in the timeline of the order (e.g. the tracking number)
+	Note string `json:"note" validate:"omitempty,max=500"`
+}
+
+// Answer of a cursor that isn't one of a list with the same sort
+func InvalidCursor() error {
+	return apierror.Validation(apierror.Detail{Field: "cursor", Message: "is invalid, or from a list with another sort"})
+}
+
+// Rows of a list page when no limit is given
+const DefaultLimit = 20
+
+// Page of a list, read from the query: at most 'limit' rows after the
+// 'cursor' given with the previous page
+type ListReq struct {
+	Limit  int    `json:"limit" query:"limit" validate:"omitempty,min=1,max=100"`
+	Cursor string `json:"cursor" query:"cursor"`
+}
+
+// Page of the products, sorted by 'sort' ('-' prefix for a descending order)
+type ProductListReq struct {
+	ListReq
+	Sort        string `json:"sort" query:"sort" validate:"omitempty,oneof=id -id brand_name -brand_name product_price -product_price"`
+	BrandName   string `json:"brand_name" query:"brand_name"`
+	RamCapacity string `json:"ram_capacity" query:"ram_capacity"`
+}
+
+// Page of the orders
+type OrderListReq struct {
+	ListReq
+	Sort          string `json:"sort" query:"sort" validate:"omitempty,oneof=id -id brand_name -brand_name total_price -total_price"`
+	BrandName     string `json:"brand_name" query:"brand_name"`
+	PaymentStatus string `json:"payment_status" query:"payment_status"`
+}
+
+// Page of the order statuses
+type OrderStatusListReq struct {
+	ListReq
+	Sort          string `json:"sort" query:"sort" validate:"omitempty,oneof=id -id order_status -order_status"`
+	OrderStatus   string `json:"order_status" query:"order_status" validate:"omitempty,oneof=pending_payment paid packed shipped delivered cancelled refunded returned"`
+	PaymentStatus string `json:"payment_status" query:"payment_status"`
+}
+
+// Search of the products: the words of 'q' in their brand or RAM capacity,
+// narrowed by the other filters
+type ProductSearchReq struct {
+	Limit    int     `json:"limit" query:"limit" validate:"omitempty,min=1,max=100"`
+	Q        string  `json:"q" query:"q" validate:"omitempty,max=200"`
+	Brand    string  `json:"brand" query:"brand"`
+	Ram      string  `json:"ram" query:"ram"`
+	MinPrice float64 `json:"min_price" query:"min_price" validate:"omitempty,min=0"`
+	MaxPrice float64 `json:"max_price" query:"max_price" validate:"omitempty,min=0"`
+}
+
+// Mappers of the requests to the models
+
+func (req SignupReq) User() models.User {
+	return models.User{Username: req.Username, Email: req.Email, Password: req.Password, Role: req.Role}
+}
+
+func (req LoginReq) User() models.User {
+	return models.User{Email: req.Email, Password: req.Password}
+}
+
+func (req ProductReq) Product() models.ProductInfo {
+	return models.ProductInfo{BrandName: req.BrandName, ProductPrice: req.ProductPrice, RamCapacity: req.RamCapacity, RamPrice: req.RamPrice}
+}
+
+// Apply the given details to product
+func (req ProductUpdateReq) Apply(product models.ProductInfo) models.ProductInfo {
+	if req.BrandName != "" {
+		product.BrandName = req.BrandName
+	}
+	if req.ProductPrice != "" {
+		product.ProductPrice = req.ProductPrice
+	}
+	if req.RamCapacity != "" {
+		product.RamCapacity = req.RamCapacity
+	}
+	if req.RamPrice != "" {
+		product.RamPrice = req.RamPrice
+	}
+	return product
+}
+
+func (req OrderReq) Order() models.OrderProductInfo {
+	return models.OrderProductInfo{
+		BrandName:    req.BrandName,
+		ProductPrice: req.ProductPrice,
+		RamCapacity:  req.RamCapacity,
+		RamPrice:     req.RamPrice,
+		DvdRwDrive:   req.DvdRwDrive,
+		Name:         req.Name,
+		Address:      req.Address,
+		PhoneNumber:  req.PhoneNumber,
+		//A new order waits for its payment
+		Status:        models.OrderPendingPayment,
+		PaymentStatus: models.OrderPendingPayment.PaymentStatus(),
+	}
+}
+
+func (req ProductListReq) Query() (repository.ListQuery, error) {
+	return req.query(req.Sort, map[string]string{"brand_name": req.BrandName, "ram_capacity": req.RamCapacity})
+}
+
+func (req OrderListReq) Query() (repository.ListQuery, error) {
+	return req.query(req.Sort, map[string]string{"brand_name": req.BrandName, "payment_status": req.PaymentStatus})
+}
+
+func (req OrderStatusListReq) Query() (repository.ListQuery, error) {
+	return req.query(req.Sort, map[string]string{"order_status": req.OrderStatus, "payment_status": req.PaymentStatus})
+}
+
+func (req ProductSearchReq) Search() (repository.ProductSearch, error) {
+	search := repository.ProductSearch{
+		Text:        req.Q,
+		BrandName:   req.Brand,
+		RamCapacity: req.Ram,
+		MinPrice:    req.MinPrice,
+		MaxPrice:    req.MaxPrice,
+		Limit:       req.Limit,
+	}
+	if search.Limit == 0 {
+		search.Limit = DefaultLimit
+	}
+	if req.MaxPrice > 0 && req.MaxPrice < req.MinPrice {
+		return search, apierror.Validation(apierror.Detail{Field: "max_price", Message: "must be at least min_price"})
+	}
+	return search, nil
+}
+
+// Query of the page, with the given filters (the empty ones are left out)
+func (req ListReq) query(sort string, filters map[string]string) (repository.ListQuery, error) {
+	query := repository.ListQuery{Limit: req.Limit, Sort: sort, Filters: map[string]string{}}
+	if query.Limit == 0 {
+		query.Limit = DefaultLimit
+	}
+	for name, value := range filters {
+		if value != "" {
+			query.Filters[name] = value
+		}
+	}
+	if req.Cursor != "" {
+		after, err := repository.DecodeCursor(req.Cursor, sort)
+		if err != nil {
+			return query, InvalidCursor()
+		}
+		query.After = after
+	}
+	return query, nil
+}
@@ -0,0 +1,179 @@
+package dto
+
+import (
+	//user defined package(s)
+	"online/models"
+	"online/repository"
+
+	//Inbuild package(s)
+	"time"
+)
+
+// Response bodies of the API, built from the models by the mappers below.
+// They never hold password hashes nor the ids of users and roles.
+
+// User signed up
+type UserResp struct {
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	Role     string `json:"role"`
+}
+
+func NewUserResp(user models.User) UserResp {
+	return UserResp{Username: user.Username, Email: user.Email, Role: user.Role}
+}
+
+// Product of the catalog
+type ProductResp struct {
+	ProductId    uint   `json:"product_id"`
+	BrandName    string `json:"brand_name"`
+	ProductPrice string `json:"product_price"`
+	RamCapacity  string `json:"ram_capacity"`
+	RamPrice     string `json:"ram_price"`
+}
+
+func NewProductResp(product models.ProductInfo) ProductResp {
+	return ProductResp{
+		ProductId:    product.ProductId,
+		BrandName:    product.BrandName,
+		ProductPrice: product.ProductPrice,
+		RamCapacity:  product.RamCapacity,
+		RamPrice:     product.RamPrice,
+	}
+}
+
+func NewProductResps(products []models.ProductInfo) []ProductResp {
+	resps := make([]ProductResp, len(products))
+	for index, product := range products {
+		resps[index] = NewProductResp(product)
+	}
+	return resps
+}
+
+// Order placed by a user
+type OrderResp struct {
+	OrderId       uint   `json:"order_id"`
+	BrandName     string `json:"brand_name"`
+	ProductPrice  string `json:"product_price"`
+	RamCapacity   string `json:"ram_capacity"`
+	RamPrice      string `json:"ram_price"`
+	DvdRwDrive    bool   `json:"dvd_rw_drive"`
+	Name          string `json:"name"`
+	Address       string `json:"address"`
+	PhoneNumber   string `json:"phone_number"`
+	TotalPrice    string `json:"total_price"`
+	OrderStatus   string `json:"order_status"`
+	PaymentStatus string `json:"payment_status"`
+}
+
+func NewOrderResp(order models.OrderProductInfo) OrderResp {
+	return OrderResp{
+		OrderId:       order.OrderId,
+		BrandName:     order.BrandName,
+		ProductPrice:  order.ProductPrice,
+		RamCapacity:   order.RamCapacity,
+		RamPrice:      order.RamPrice,
+		DvdRwDrive:    order.DvdRwDrive,
+		Name:          order.Name,
+		Address:       order.Address,
+		PhoneNumber:   order.PhoneNumber,
+		TotalPrice:    order.TotalPrice,
+		OrderStatus:   string(order.Status),
+		PaymentStatus: order.PaymentStatus,
+	}
+}
+
+func NewOrderResps(orders []models.OrderProductInfo) []OrderResp {
+	resps := make([]OrderResp, len(orders))
+	for index, order := range orders {
+		resps[index] = NewOrderResp(order)
+	}
+	return resps
+}
+
+// State of an order, with its delivery details
+type OrderStatusResp struct {
+	OrderId         uint   `json:"order_id"`
+	Name            string `json:"name"`
+	Address         string `json:"address"`
+	PhoneNumber     string `json:"phone_number"`
+	PaymentStatus   string `json:"payment_status"`
+	OrderStatus     string `json:"order_status"`
+	BrandName       string `json:"brand_name"`
+	IncludedProduct string `json:"included_product"`
+	TotalPrice      string `json:"total_price"`
+}
+
+func NewOrderStatusResp(order models.OrderProductInfo) OrderStatusResp {
+	resp := OrderStatusResp{
+		OrderId:         order.OrderId,
+		Name:            order.Name,
+		Address:         order.Address,
+		PhoneNumber:     order.PhoneNumber,
+		PaymentStatus:   order.PaymentStatus,
+		OrderStatus:     string(order.Status),
+		BrandName:       order.BrandName,
+		IncludedProduct: "None",
+		TotalPrice:      order.TotalPrice,
+	}
+	if order.DvdRwDrive {
+		resp.IncludedProduct = "DVD RW Drive"
+	}
+	return resp
+}
+
+// Change of the state of an order, by a user of the role Actor ("" From for
+// the order placed)
+type OrderEventResp struct {
+	From  string    `json:"from,omitempty"`
+	To    string    `json:"to"`
+	Actor string    `json:"actor"`
+	Note  string    `json:"note,omitempty"`
+	At    time.Time `json:"at"`
+}
+
+func NewOrderEventResps(events []models.OrderEvent) []OrderEventResp {
+	resps := make([]OrderEventResp, len(events))
+	for index, event := range events {
+		resps[index] = OrderEventResp{
+			From:  string(event.From),
+			To:    string(event.To),
+			Actor: event.ActorRole,
+			Note:  event.Note,
+			At:    event.CreatedAt,
+		}
+	}
+	return resps
+}
+
+// Cursor of the next page, "" on the last page
+func NextCursor(page repository.Page) string {
+	if page.Next == nil {
+		return ""
+	}
+	return page.Next.Encode()
+}
+
+// Count of the found products having a value
+type FacetResp struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+// Facets of a product search, by the name of their filter
+type FacetsResp struct {
+	Brand []FacetResp `json:"brand"`
+	Ram   []FacetResp `json:"ram"`
+}
+
+func NewFacetsResp(result repository.ProductSearchResult) FacetsResp {
+	return FacetsResp{Brand: newFacetResps(result.BrandNames), Ram: newFacetResps(result.RamCapacities)}
+}
+
+func newFacetResps(facets []repository.Facet) []FacetResp {
+	resps := make([]FacetResp, len(facets))
+	for index, facet := range facets {
+		resps[index] = FacetResp{Value: facet.Value, Count: facet.Count}
+	}
+	return resps
+}
@@ -0,0 +1,245 @@
+package handler
+
+import (
+	//Inbuild package(s)
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	//User defined package(s)
+	"online/Lookup"
+	"online/apierror"
+	"online/config"
+	"online/driver"
+	"online/middleware"
+	"online/models"
+	"online/repository"
+	"online/validation"
+
+	//Third party package(s)
+	"github.com/labstack/echo"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// Id which never exists in a fresh test database
+const MissingId = 999999
+
+// Signed with a different key, so it never passes the auth middleware
+const InvalidToken = "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9.eyJFeHBpcmVzQXQiOjE2OTE3MzQ4MjcsIklzc3VlZEF0IjoxNjkxNjQ4ND" +
+	"I3LCJSb2xlLWlkIjoiMSIsIlVzZXItaWQiOiIxIn0.lVTEa9Ddpu-EyeXNQZYyGw8JpNeBhgvFt8INc-n-8C"
+
+// Password used for every user created by the fixtures
+const TestPassword = "12345678"
+
+// Login token settings of the tests
+var testJWT = config.JWT{Secret: "test-secret", Expiry: time.Hour}
+
+// Everything a handler test needs: an isolated repository, the handlers and
+// middleware built on top of it, and a logged-in admin and user
+type testEnv struct {
+	Repo       repository.Repository
+	Handler    Database
+	Middleware middleware.Database
+	AdminToken string
+	UserToken  string
+	User       models.User
+}
+
+var (
+	testDb     *gorm.DB
+	testDbErr  error
+	testDbOnce sync.Once
+)
+
+// Echo instance answering errors like the server does
+func newEcho() *echo.Echo {
+	e := echo.New()
+	e.HTTPErrorHandler = apierror.Handler
+	e.Validator = validation.New()
+	return e
+}
+
+// Decode the error envelope of a response
+func apiError(t *testing.T, resp *httptest.ResponseRecorder) map[string]interface{} {
+	t.Helper()
+	var body struct {
+		Error map[string]interface{} `json:"error"`
+	}
+	if err := json.Unmarshal(resp.Body.Bytes(), &body); err != nil || body.Error == nil {
+		t.Fatalf("expected an error envelope, got %s", resp.Body)
+	}
+	return body.Error
+}
+
+// Open and migrate the 'TEST_DBNAME' database once per run
+func openTestDb(t testing.TB) *gorm.DB {
+	t.Helper()
+	testDbOnce.Do(func() {
+		//The database settings come from the environment, like the server's
+		cfg, err := config.Parse(flag.NewFlagSet("test", flag.ContinueOnError))
+		if err != nil {
+			testDbErr = err
+			return
+		}
+		//The tests needing SQL run on sqlite when no database is set
+		if os.Getenv("DB_DRIVER") == "" {
+			cfg.Database.Driver, cfg.Database.TestName = "sqlite", ":memory:"
+		}
+		if testDb, testDbErr = driver.TestDbConnection(cfg.Database); testDbErr == nil {
+			testDbErr = Lookup.UpdateDatabase(testDb)
+		}
+	})
+	if testDbErr != nil {
+		t.Fatalf("migrate test database: %v", testDbErr)
+	}
+	return testDb
+}
+
+// Create a repository that no other test can see. The suite runs in memory
+// unless 'DB_DRIVER' is set, in which case every test runs inside its own
+// transaction on the 'TEST_DBNAME' database and is rolled back at the end
+// (e.g. DB_DRIVER=sqlite TEST_DBNAME=:memory:)
+func newTestRepository(t testing.TB) repository.Repository {
+	t.Helper()
+	if os.Getenv("DB_DRIVER") == "" {
+		return repository.NewMemoryRepository()
+	}
+	return repository.NewGormRepository(openTestTx(t))
+}
+
+// Begin a transaction on the test database, rolled back at the end of the test
+func openTestTx(t testing.TB) *gorm.DB {
+	t.Helper()
+	tx := openTestDb(t).Begin()
+	if tx.Error != nil {
+		t.Fatalf("begin transaction: %v", tx.Error)
+	}
+	t.Cleanup(func() { tx.Rollback() })
+	return tx
+}
+
+// Create an isolated environment with one admin and one user already logged in
+func newTestEnv(t testing.TB) testEnv {
+	t.Helper()
+	return newTestEnvOn(t, newTestRepository(t))
+}
+
+// Create the environment of newTestEnv on the given repository
+func newTestEnvOn(t testing.TB, repo repository.Repository) testEnv {
+	t.Helper()
+	env := testEnv{
+		Repo:       repo,
+		Handler:    New(repo, testJWT),
+		Middleware: middleware.Database{Tokens: repo, JWT: testJWT},
+	}
+	admin := createUser(t, repo, "Ajith", "ajith@gmail.com", "admin")
+	env.AdminToken = createToken(t, repo, admin)
+	env.User = createUser(t, repo, "Vijay", "vijay@gmail.com", "user")
+	env.UserToken = createToken(t, repo, env.User)
+	return env
+}
+
+// Create a user with the given role and 'TestPassword'
+func createUser(t testing.TB, repo repository.Repository, name, email, role string) models.User {
+	t.Helper()
+	password, err := bcrypt.GenerateFromPassword([]byte(TestPassword), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("hash password: %v", err)
+	}
+	user := models.User{Username: name, Email: email, Password: string(password), Role: role}
+	roles, err := repo.ReadRoleIdByRole(context.Background(), user)
+	if err != nil {
+		t.Fatalf("read role %q: %v", role, err)
+	}
+	user.RoleId = roles.RoleId
+	if err := repo.CreateUser(context.Background(), user); err != nil {
+		t.Fatalf("create user %q: %v", email, err)
+	}
+	user, err = repo.ReadUserByEmail(context.Background(), user)
+	if err != nil {
+		t.Fatalf("read user %q: %v", email, err)
+	}
+	return user
+}
+
+// Create and store a login token for the user
+func createToken(t testing.TB, repo repository.Repository, user models.User) string {
+	t.Helper()
+	token, err := middleware.Database{Tokens: repo, JWT: testJWT}.CreateToken(user)
+	if err != nil {
+		t.Fatalf("create token: %v", err)
+	}
+	if err := repo.AddToken(context.Background(), models.Authentication{UserId: user.UserId, Token: token}); err != nil {
+		t.Fatalf("add token: %v", err)
+	}
+	return token
+}
+
+// Create a product and return it with its product-id
+func createProduct(t testing.TB, repo repository.Repository, brand, price, ramCapacity, ramPrice string) models.ProductInfo {
+	t.Helper()
+	product := models.ProductInfo{BrandName: brand, ProductPrice: price, RamCapacity: ramCapacity, RamPrice: ramPrice}
+	if err := repo.CreateProduct(context.Background(), product); err != nil {
+		t.Fatalf("create product: %v", err)
+	}
+	created, err := repo.ReadProductIdByProductData(context.Background(), models.OrderProductInfo{
+		BrandName: brand, ProductPrice: price, RamCapacity: ramCapacity, RamPrice: ramPrice,
+	})
+	if err != nil {
+		t.Fatalf("read product: %v", err)
+	}
+	product.ProductId = created.ProductId
+	return product
+}
+
+// Create an order of the product (with a DVD RW drive) waiting for its payment
+func createOrder(t testing.TB, repo repository.Repository, user models.User, product models.ProductInfo) models.OrderProductInfo {
+	t.Helper()
+	productPrice, _ := strconv.Atoi(product.ProductPrice)
+	ramPrice, _ := strconv.Atoi(product.RamPrice)
+	order := models.OrderProductInfo{
+		UserId:       user.UserId,
+		BrandName:    product.BrandName,
+		ProductPrice: product.ProductPrice,
+		RamCapacity:  product.RamCapacity,
+		RamPrice:     product.RamPrice,
+		DvdRwDrive:   true,
+		Name:         "Hari",
+		Address:      "5th street",
+		PhoneNumber:  "9876543210",
+		TotalPrice:   strconv.Itoa(productPrice + ramPrice + 3000),
+	}
+	placed := models.OrderEvent{ActorId: user.UserId, ActorRole: "user"}
+	orderId, err := repo.CreateOrder(context.Background(), order, placed)
+	if err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+	created, err := repo.ReadOrderByOrderId(context.Background(), fmt.Sprint(orderId))
+	if err != nil {
+		t.Fatalf("read order: %v", err)
+	}
+	return created
+}
+
+// Move the order through the given states and return it in the last one
+func moveOrder(t testing.TB, repo repository.Repository, order models.OrderProductInfo, states ...models.OrderState) models.OrderProductInfo {
+	t.Helper()
+	for _, state := range states {
+		from := order.Status
+		if err := order.Transition(state); err != nil {
+			t.Fatalf("move order: %v", err)
+		}
+		if err := repo.UpdateOrderState(context.Background(), order, models.OrderEvent{From: from, ActorRole: "admin"}); err != nil {
+			t.Fatalf("save order state: %v", err)
+		}
+	}
+	return order
+}
@@ -0,0 +1,106 @@
+package handler
+
+import (
+	//user defined packages
+	"online/Lookup"
+	"online/logs"
+
+	//Inbuild packages
+	"context"
+	"net/http"
+	"time"
+
+	//Third party packages
+	"github.com/labstack/echo"
+	"gorm.io/gorm"
+)
+
+// How long a readiness check may wait for the database
+const readyTimeout = 2 * time.Second
+
+// Liveness and readiness probes of the server
+type Health struct {
+	Db *gorm.DB
+}
+
+// Result of one readiness check, 'ok' or 'unavailable'. The probe needs no
+// token, so the cause of a failure is only logged, Error holding fixed texts.
+type check struct {
+	Status          string `json:"status"`
+	Error           string `json:"error,omitempty"`
+	LatencyMs       int64  `json:"latency_ms,omitempty"`
+	CurrentVersion  *uint  `json:"current_version,omitempty"`
+	ExpectedVersion *uint  `json:"expected_version,omitempty"`
+}
+
+// Liveness: the process is up and serving requests
+func (h Health) Live(c echo.Context) error {
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  200,
+		"message": "alive",
+	})
+}
+
+// Readiness: the database answers and every migration is applied
+func (h Health) Ready(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), readyTimeout)
+	defer cancel()
+
+	checks := map[string]check{}
+	errs := map[string]error{}
+	checks["database"], errs["database"] = h.pingDatabase(ctx)
+	checks["migrations"], errs["migrations"] = h.checkMigrations(ctx)
+	for name, err := range errs {
+		if err != nil {
+			logs.Request(c).Error("Readiness check failed", "check", name, "error", err)
+		}
+	}
+	for _, result := range checks {
+		if result.Status != "ok" {
+			return c.JSON(http.StatusServiceUnavailable, map[string]interface{}{
+				"status":  503,
+				"message": "not ready",
+				"checks":  checks,
+			})
+		}
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  200,
+		"message": "ready",
+		"checks":  checks,
+	})
+}
+
+func (h Health) pingDatabase(ctx context.Context) (check, error) {
+	start := time.Now()
+	sqlDb, err := h.Db.DB()
+	if err == nil {
+		err = sqlDb.PingContext(ctx)
+	}
+	if err != nil {
+		return check{Status: "unavailable"}, err
+	}
+	return check{Status: "ok", LatencyMs: time.Since(start).Milliseconds()}, nil
+}
+
+func (h Health) checkMigrations(ctx context.Context) (check, error) {
+	statuses, err := Lookup.Status(h.Db.WithContext(ctx))
+	if err != nil {
+		return check{Status: "unavailable"}, err
+	}
+	var current, expected uint
+	pending := 0
+	for _, status := range statuses {
+		expected = status.Version
+		if status.Applied {
+			current = status.Version
+		} else {
+			pending++
+		}
+	}
+	result := check{Status: "ok", CurrentVersion: &current, ExpectedVersion: &expected}
+	if pending > 0 {
+		result.Status, result.Error = "unavailable", "pending migrations"
+	}
+	return result, nil
+}
@@ -0,0 +1,98 @@
+package handler
+
+import (
+	//Inbuild package(s)
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	//User defined package(s)
+	"online/Lookup"
+
+	//Third party package(s)
+	"github.com/glebarez/sqlite"
+	"github.com/labstack/echo"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// Open an empty sqlite database private to the test
+func openHealthDb(t *testing.T) *gorm.DB {
+	t.Helper()
+	Db, err := gorm.Open(sqlite.Open("file:"+t.Name()+"?mode=memory&cache=shared"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	sqlDb, _ := Db.DB()
+	t.Cleanup(func() { sqlDb.Close() })
+	return Db
+}
+
+// Call a probe and decode its response
+func probe(t *testing.T, health Health, url string) (int, map[string]interface{}) {
+	t.Helper()
+	e := echo.New()
+	e.GET("/healthz", health.Live)
+	e.GET("/readyz", health.Ready)
+	resp := httptest.NewRecorder()
+	e.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, url, nil))
+	var body map[string]interface{}
+	if err := json.Unmarshal(resp.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode %s: %v", resp.Body, err)
+	}
+	return resp.Code, body
+}
+
+func TestHealthz(t *testing.T) {
+	if code, _ := probe(t, Health{Db: openHealthDb(t)}, "/healthz"); code != http.StatusOK {
+		t.Fatalf("expected: %d, got: %d", http.StatusOK, code)
+	}
+}
+
+func TestReadyz(t *testing.T) {
+	Db := openHealthDb(t)
+	health := Health{Db: Db}
+
+	t.Run("pending migrations", func(t *testing.T) {
+		code, body := probe(t, health, "/readyz")
+		if code != http.StatusServiceUnavailable {
+			t.Fatalf("expected: %d, got: %d", http.StatusServiceUnavailable, code)
+		}
+		migrations := body["checks"].(map[string]interface{})["migrations"].(map[string]interface{})
+		if migrations["status"] != "unavailable" || migrations["current_version"] != 0.0 {
+			t.Fatalf("expected the migrations check to fail at version 0, got %v", migrations)
+		}
+	})
+
+	t.Run("ready", func(t *testing.T) {
+		if err := Lookup.UpdateDatabase(Db); err != nil {
+			t.Fatal(err)
+		}
+		code, body := probe(t, health, "/readyz")
+		if code != http.StatusOK {
+			t.Fatalf("expected: %d, got: %d (%v)", http.StatusOK, code, body)
+		}
+		migrations := body["checks"].(map[string]interface{})["migrations"].(map[string]interface{})
+		if migrations["current_version"] != migrations["expected_version"] {
+			t.Fatalf("expected the latest version, got %v", migrations)
+		}
+	})
+
+	t.Run("database down", func(t *testing.T) {
+		sqlDb, _ := Db.DB()
+		sqlDb.Close()
+		code, body := probe(t, health, "/readyz")
+		if code != http.StatusServiceUnavailable {
+			t.Fatalf("expected: %d, got: %d", http.StatusServiceUnavailable, code)
+		}
+		database := body["checks"].(map[string]interface{})["database"].(map[string]interface{})
+		//The cause, naming the driver and the host, is only logged
+		if database["status"] != "unavailable" || database["error"] != nil {
+			t.Fatalf("expected the database check to fail without its cause, got %v", database)
+		}
+		if code, _ := probe(t, health, "/healthz"); code != http.StatusOK {
+			t.Fatalf("liveness should not depend on the database, got: %d", code)
+		}
+	})
+}
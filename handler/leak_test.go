@@ -0,0 +1,69 @@
+package handler
+
+import (
+	//Inbuild package(s)
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// Parts of a response revealing a password hash or an internal id
+var leaks = []string{`"password"`, "$2a$", `"user_id"`, `"role_id"`, `"User-id"`, `"Role-id"`}
+
+// Call every endpoint, as both roles, and check no response leaks a
+// password hash or an internal id
+func TestResponsesDoNotLeak(t *testing.T) {
+	env := newTestEnv(t)
+	database, middleware := env.Handler, env.Middleware
+	product := createProduct(t, env.Repo, "hp", "20000", "2GB", "2000")
+	order := createOrder(t, env.Repo, env.User, product)
+	e := newEcho()
+	e.POST("/signup", database.Signup)
+	e.POST("/login", database.Login)
+	e.POST("/admin/addProduct", database.PostProduct, middleware.AuthMiddleware)
+	e.GET("/common/getAllProducts", database.GetAllProducts, middleware.AuthMiddleware)
+	e.PUT("/admin/updateProduct/:product_id", database.UpdateProductById, middleware.AuthMiddleware)
+	e.POST("/user/addOrder", database.AddOrder, middleware.AuthMiddleware)
+	e.GET("/common/getOrders", database.GetOrders, middleware.AuthMiddleware)
+	e.POST("/user/payment/:order_id", database.Payment, middleware.AuthMiddleware)
+	e.PUT("/admin/updateOrderStatus/:order_id", database.UpdateOrderStatusById, middleware.AuthMiddleware)
+	e.GET("/common/getOrderStatus/:order_id", database.GetOrderStatusById, middleware.AuthMiddleware)
+	e.GET("/admin/getOrderStatuses", database.GetAllOrderStatus, middleware.AuthMiddleware)
+	e.GET("/orders/:order_id/timeline", database.GetOrderTimeline, middleware.AuthMiddleware)
+
+	requests := []struct {
+		method, url, token, body string
+	}{
+		{http.MethodPost, "/signup", "", `{"username":"hari","email":"hari@gmail.com","password":"12345678","role":"user"}`},
+		{http.MethodPost, "/signup", "", `{"username":"hari","email":"hari@gmail.com","password":"12345678","role":"user"}`},
+		{http.MethodPost, "/login", "", `{"email":"vijay@gmail.com","password":"12345678"}`},
+		{http.MethodPost, "/login", "", `{"email":"vijay@gmail.com","password":"wrong password"}`},
+		{http.MethodPost, "/admin/addProduct", env.AdminToken, `{"brand_name":"dell","product_price":"30000","ram_capacity":"4GB","ram_price":"3000"}`},
+		{http.MethodGet, "/common/getAllProducts", env.UserToken, ""},
+		{http.MethodPut, fmt.Sprintf("/admin/updateProduct/%d", product.ProductId), env.AdminToken, `{"ram_price":"2000"}`},
+		{http.MethodPost, "/user/addOrder", env.UserToken, `{"brand_name":"hp","product_price":"20000","ram_capacity":"2GB","ram_price":"2000","name":"Hari","address":"5th street","phone_number":"9876543210"}`},
+		{http.MethodGet, "/common/getOrders", env.UserToken, ""},
+		{http.MethodGet, "/common/getOrders", env.AdminToken, ""},
+		{http.MethodPost, fmt.Sprintf("/user/payment/%d", order.OrderId), env.UserToken, fmt.Sprintf(`{"payment":"%s"}`, order.TotalPrice)},
+		{http.MethodPut, fmt.Sprintf("/admin/updateOrderStatus/%d", order.OrderId), env.AdminToken, `{"order_status":"packed"}`},
+		{http.MethodGet, fmt.Sprintf("/common/getOrderStatus/%d", order.OrderId), env.UserToken, ""},
+		{http.MethodGet, fmt.Sprintf("/orders/%d/timeline", order.OrderId), env.UserToken, ""},
+		{http.MethodGet, "/admin/getOrderStatuses", env.AdminToken, ""},
+	}
+	for _, request := range requests {
+		req := httptest.NewRequest(request.method, request.url, strings.NewReader(request.body))
+		req.Header.Set("Content-Type", "application/json")
+		if request.token != "" {
+			req.Header.Set("Authorization", "Bearer "+request.token)
+		}
+		resp := httptest.NewRecorder()
+		e.ServeHTTP(resp, req)
+		for _, leak := range leaks {
+			if strings.Contains(resp.Body.String(), leak) {
+				t.Errorf("%s %s: response leaks %s: %s", request.method, request.url, leak, resp.Body)
+			}
+		}
+	}
+}
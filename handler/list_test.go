@@ -0,0 +1,182 @@
+package handler
+
+import (
+	//Inbuild package(s)
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	//User defined package(s)
+	"online/models"
+	"online/repository"
+
+	//Third party package(s)
+	"github.com/labstack/echo"
+)
+
+// Body of a list page
+type listPage struct {
+	Products      []map[string]interface{} `json:"Products"`
+	Orders        []map[string]interface{} `json:"Orders"`
+	OrderStatuses []map[string]interface{} `json:"Order Statuses"`
+	NextCursor    string                   `json:"next_cursor"`
+	TotalCount    int64                    `json:"total_count"`
+}
+
+// Get a list page, failing the test unless it answers 200
+func getPage(t *testing.T, e *echo.Echo, token, path string, query url.Values) listPage {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, path+"?"+query.Encode(), nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	resp := httptest.NewRecorder()
+	e.ServeHTTP(resp, req)
+	if resp.Code != http.StatusOK {
+		t.Fatalf("GET %s?%s: expected: %d, got: %d %s", path, query.Encode(), http.StatusOK, resp.Code, resp.Body)
+	}
+	var page listPage
+	if err := json.Unmarshal(resp.Body.Bytes(), &page); err != nil {
+		t.Fatalf("decode %s: %v", resp.Body, err)
+	}
+	return page
+}
+
+// Follow the cursors from the first page and return the values of field of every row
+func walk(t *testing.T, e *echo.Echo, token, path, key, field string, query url.Values) (values []string, pages int) {
+	t.Helper()
+	for {
+		page := getPage(t, e, token, path, query)
+		rows := map[string][]map[string]interface{}{"Products": page.Products, "Orders": page.Orders, "Order Statuses": page.OrderStatuses}[key]
+		for _, row := range rows {
+			values = append(values, fmt.Sprint(row[field]))
+		}
+		pages++
+		if page.NextCursor == "" {
+			return values, pages
+		}
+		query.Set("cursor", page.NextCursor)
+		if pages > 10 {
+			t.Fatalf("the cursors of %s never end", path)
+		}
+	}
+}
+
+func TestListProductPages(t *testing.T) {
+	env := newTestEnv(t)
+	database, middleware := env.Handler, env.Middleware
+	for _, product := range [][]string{
+		{"hp", "20000", "2GB", "2000"},
+		{"dell", "35000", "4GB", "3000"},
+		{"lenovo", "9000", "2GB", "2000"},
+		{"hp", "120000", "8GB", "5000"},
+		{"asus", "35000", "8GB", "5000"},
+	} {
+		createProduct(t, env.Repo, product[0], product[1], product[2], product[3])
+	}
+	e := newEcho()
+	e.GET("/products", database.GetAllProducts, middleware.AuthMiddleware)
+
+	t.Run("Pages sorted by price", func(t *testing.T) {
+		query := url.Values{"limit": {"2"}, "sort": {"-product_price"}}
+		prices, pages := walk(t, e, env.UserToken, "/products", "Products", "product_price", query)
+		//Compared as numbers, the ties ordered by id in the same direction
+		if want, got := "120000 35000 35000 20000 9000", strings.Join(prices, " "); want != got || pages != 3 {
+			t.Fatalf("expected: %s in 3 pages, got: %s in %d pages", want, got, pages)
+		}
+		brands, _ := walk(t, e, env.UserToken, "/products", "Products", "brand_name", url.Values{"limit": {"2"}, "sort": {"-product_price"}})
+		if want, got := "hp asus dell hp lenovo", strings.Join(brands, " "); want != got {
+			t.Fatalf("expected: %s, got: %s", want, got)
+		}
+	})
+
+	t.Run("Filtered by brand", func(t *testing.T) {
+		page := getPage(t, e, env.AdminToken, "/products", url.Values{"brand_name": {"hp"}, "limit": {"1"}})
+		if len(page.Products) != 1 || page.TotalCount != 2 || page.NextCursor == "" {
+			t.Fatalf("expected 1 of the 2 hp products and a next page, got %+v", page)
+		}
+	})
+
+	t.Run("Invalid queries", func(t *testing.T) {
+		sortedByPrice := getPage(t, e, env.UserToken, "/products", url.Values{"limit": {"1"}, "sort": {"product_price"}})
+		for _, query := range []url.Values{
+			{"limit": {"101"}},
+			{"limit": {"0"}, "sort": {"password"}},
+			{"cursor": {"not-a-cursor"}},
+			//A cursor only goes with the sort of its list
+			{"cursor": {sortedByPrice.NextCursor}, "sort": {"brand_name"}},
+			//Decoding, but with a price that isn't a number
+			{"cursor": {repository.Cursor{Sort: "product_price", Value: "abc", Id: 1}.Encode()}, "sort": {"product_price"}},
+		} {
+			req := httptest.NewRequest(http.MethodGet, "/products?"+query.Encode(), nil)
+			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", env.UserToken))
+			resp := httptest.NewRecorder()
+			e.ServeHTTP(resp, req)
+			if want, got := http.StatusUnprocessableEntity, resp.Code; want != got {
+				t.Errorf("%s: expected: %d, got: %d", query.Encode(), want, got)
+			}
+		}
+	})
+}
+
+func TestListOrderPages(t *testing.T) {
+	env := newTestEnv(t)
+	database, middleware := env.Handler, env.Middleware
+	other := createUser(t, env.Repo, "Surya", "surya@gmail.com", "user")
+	otherToken := createToken(t, env.Repo, other)
+	hp := createProduct(t, env.Repo, "hp", "20000", "2GB", "2000")
+	dell := createProduct(t, env.Repo, "dell", "35000", "4GB", "3000")
+	for _, product := range []models.ProductInfo{hp, dell, hp} {
+		createOrder(t, env.Repo, env.User, product)
+	}
+	createOrder(t, env.Repo, other, dell)
+	e := newEcho()
+	e.GET("/orders", database.GetOrders, middleware.AuthMiddleware)
+	e.GET("/order-statuses", database.GetAllOrderStatus, middleware.AuthMiddleware)
+
+	t.Run("Every order for the admin", func(t *testing.T) {
+		brands, pages := walk(t, e, env.AdminToken, "/orders", "Orders", "brand_name", url.Values{"limit": {"3"}})
+		if want, got := "hp dell hp dell", strings.Join(brands, " "); want != got || pages != 2 {
+			t.Fatalf("expected: %s in 2 pages, got: %s in %d pages", want, got, pages)
+		}
+	})
+
+	t.Run("Only their orders for a user", func(t *testing.T) {
+		page := getPage(t, e, otherToken, "/orders", url.Values{})
+		if len(page.Orders) != 1 || page.TotalCount != 1 || page.NextCursor != "" {
+			t.Fatalf("expected the single order of the user, got %+v", page)
+		}
+		page = getPage(t, e, env.UserToken, "/orders", url.Values{"brand_name": {"hp"}, "sort": {"-total_price"}})
+		if len(page.Orders) != 2 || page.TotalCount != 2 {
+			t.Fatalf("expected the 2 hp orders of the user, got %+v", page)
+		}
+	})
+
+	t.Run("Tampered cursor", func(t *testing.T) {
+		//Decoding, but with a value its sort field can't take
+		for path, sort := range map[string]string{"/orders": "-total_price", "/order-statuses": "id"} {
+			cursor := repository.Cursor{Sort: sort, Value: "abc", Id: 1}.Encode()
+			query := url.Values{"cursor": {cursor}, "sort": {sort}}
+			req := httptest.NewRequest(http.MethodGet, path+"?"+query.Encode(), nil)
+			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", env.AdminToken))
+			resp := httptest.NewRecorder()
+			e.ServeHTTP(resp, req)
+			if want, got := http.StatusUnprocessableEntity, resp.Code; want != got {
+				t.Errorf("%s: expected: %d, got: %d %s", path, want, got, resp.Body)
+			}
+		}
+	})
+
+	t.Run("Order statuses filtered by status", func(t *testing.T) {
+		page := getPage(t, e, env.AdminToken, "/order-statuses", url.Values{"order_status": {"pending_payment"}, "limit": {"2"}})
+		if len(page.OrderStatuses) != 2 || page.TotalCount != 4 || page.NextCursor == "" {
+			t.Fatalf("expected 2 of the 4 statuses waiting for a payment, got %+v", page)
+		}
+		page = getPage(t, e, env.AdminToken, "/order-statuses", url.Values{"order_status": {"shipped"}})
+		if page.TotalCount != 0 {
+			t.Fatalf("expected no shipped order, got %+v", page)
+		}
+	})
+}
@@ -0,0 +1,99 @@
+package handler
+
+import (
+	//Inbuild package(s)
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	//User defined package(s)
+	"online/repository"
+
+	//Third party package(s)
+	"github.com/labstack/echo"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// Logger counting the statements run through it, instead of printing them
+type queryCounter struct {
+	logger.Interface
+	queries int64
+}
+
+func (q *queryCounter) Trace(context.Context, time.Time, func() (string, int64), error) {
+	atomic.AddInt64(&q.queries, 1)
+}
+
+// Environment on the SQL test database (sqlite in memory unless 'DB_DRIVER'
+// is set) with the given count of orders, and the counter of its statements
+func newCountedEnv(t testing.TB, orders int) (testEnv, *queryCounter) {
+	t.Helper()
+	counter := &queryCounter{Interface: logger.Discard}
+	tx := openTestTx(t).Session(&gorm.Session{Logger: counter})
+	env := newTestEnvOn(t, repository.NewGormRepository(tx))
+	product := createProduct(t, env.Repo, "hp", "20000", "2GB", "2000")
+	for index := 0; index < orders; index++ {
+		createOrder(t, env.Repo, env.User, product)
+	}
+	return env, counter
+}
+
+// Statements run to answer the first page of the order statuses
+func countStatusQueries(t testing.TB, e *echo.Echo, env testEnv, counter *queryCounter) int64 {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/order-statuses?limit=100", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", env.AdminToken))
+	resp := httptest.NewRecorder()
+	before := atomic.LoadInt64(&counter.queries)
+	e.ServeHTTP(resp, req)
+	if resp.Code != http.StatusOK {
+		t.Fatalf("expected: %d, got: %d %s", http.StatusOK, resp.Code, resp.Body)
+	}
+	return atomic.LoadInt64(&counter.queries) - before
+}
+
+// The orders of the statuses are read with the page, not one by one
+func TestOrderStatusQueryCount(t *testing.T) {
+	var counts []int64
+	for _, orders := range []int{1, 50} {
+		//One subtest at a time, as the transaction of each locks the database
+		t.Run(fmt.Sprintf("%d orders", orders), func(t *testing.T) {
+			env, counter := newCountedEnv(t, orders)
+			e := newEcho()
+			e.GET("/order-statuses", env.Handler.GetAllOrderStatus, env.Middleware.AuthMiddleware)
+			page := getPage(t, e, env.AdminToken, "/order-statuses", url.Values{"limit": {"100"}})
+			if len(page.OrderStatuses) != orders || page.OrderStatuses[0]["brand_name"] != "hp" || page.OrderStatuses[0]["name"] != "Hari" {
+				t.Fatalf("expected the %d statuses with their orders, got %+v", orders, page.OrderStatuses)
+			}
+			counts = append(counts, countStatusQueries(t, e, env, counter))
+		})
+	}
+	if len(counts) != 2 {
+		t.FailNow()
+	}
+	if counts[0] != counts[1] {
+		t.Fatalf("expected the same statements for 1 and 50 statuses, got %d and %d", counts[0], counts[1])
+	}
+}
+
+func BenchmarkGetAllOrderStatus(b *testing.B) {
+	for _, orders := range []int{10, 100, 1000} {
+		b.Run(fmt.Sprintf("orders=%d", orders), func(b *testing.B) {
+			env, counter := newCountedEnv(b, orders)
+			e := newEcho()
+			e.GET("/order-statuses", env.Handler.GetAllOrderStatus, env.Middleware.AuthMiddleware)
+			var queries int64
+			b.ResetTimer()
+			for index := 0; index < b.N; index++ {
+				queries += countStatusQueries(b, e, env, counter)
+			}
+			b.ReportMetric(float64(queries)/float64(b.N), "queries/op")
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package handler
+
+import (
+	//Inbuild package(s)
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// Body of a search
+type searchResult struct {
+	Products   []map[string]interface{} `json:"Products"`
+	TotalCount int64                    `json:"total_count"`
+	Facets     map[string][]struct {
+		Value string `json:"value"`
+		Count int64  `json:"count"`
+	} `json:"facets"`
+}
+
+// Facet counts as "value:count" in their order
+func (r searchResult) facet(name string) string {
+	var counts []string
+	for _, facet := range r.Facets[name] {
+		counts = append(counts, fmt.Sprintf("%s:%d", facet.Value, facet.Count))
+	}
+	return strings.Join(counts, " ")
+}
+
+func TestSearchProducts(t *testing.T) {
+	env := newTestEnv(t)
+	for _, product := range [][]string{
+		{"hp", "20000", "2GB", "2000"},
+		{"dell", "35000", "4GB", "3000"},
+		{"lenovo", "9000", "2GB", "2000"},
+		{"hp", "120000", "8GB", "5000"},
+		{"asus", "35000", "8GB", "5000"},
+	} {
+		createProduct(t, env.Repo, product[0], product[1], product[2], product[3])
+	}
+	e := newEcho()
+	e.GET("/products/search", env.Handler.SearchProducts, env.Middleware.AuthMiddleware)
+
+	search := func(query url.Values) (searchResult, int) {
+		req := httptest.NewRequest(http.MethodGet, "/products/search?"+query.Encode(), nil)
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", env.UserToken))
+		resp := httptest.NewRecorder()
+		e.ServeHTTP(resp, req)
+		var result searchResult
+		if resp.Code == http.StatusOK {
+			if err := json.Unmarshal(resp.Body.Bytes(), &result); err != nil {
+				t.Fatalf("decode %s: %v", resp.Body, err)
+			}
+		}
+		return result, resp.Code
+	}
+
+	tests := []struct {
+		name                 string
+		query                url.Values
+		brands, total        string
+		brandFacet, ramFacet string
+	}{
+		{"Everything", url.Values{}, "hp dell lenovo hp asus", "5",
+			"hp:2 asus:1 dell:1 lenovo:1", "2GB:2 8GB:2 4GB:1"},
+		{"Text", url.Values{"q": {"HP"}}, "hp hp", "2",
+			"hp:2", "2GB:1 8GB:1"},
+		{"Every word", url.Values{"q": {"hp 8gb"}}, "hp", "1",
+			"hp:1", "8GB:1"},
+		//The facet of a filter ignores it
+		{"Brand filter", url.Values{"brand": {"hp"}}, "hp hp", "2",
+			"hp:2 asus:1 dell:1 lenovo:1", "2GB:1 8GB:1"},
+		{"RAM filter", url.Values{"ram": {"8GB"}}, "hp asus", "2",
+			"asus:1 hp:1", "2GB:2 8GB:2 4GB:1"},
+		{"Price range", url.Values{"min_price": {"20000"}, "max_price": {"35000"}}, "hp dell asus", "3",
+			"asus:1 dell:1 hp:1", "2GB:1 4GB:1 8GB:1"},
+		{"Limit", url.Values{"limit": {"1"}, "ram": {"2GB"}}, "hp", "2",
+			"hp:1 lenovo:1", "2GB:2 8GB:2 4GB:1"},
+		{"Nothing found", url.Values{"q": {"apple"}}, "", "0", "", ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, code := search(test.query)
+			if code != http.StatusOK {
+				t.Fatalf("expected: %d, got: %d", http.StatusOK, code)
+			}
+			var brands []string
+			for _, product := range result.Products {
+				brands = append(brands, fmt.Sprint(product["brand_name"]))
+			}
+			if got := strings.Join(brands, " "); got != test.brands {
+				t.Errorf("products: expected: %q, got: %q", test.brands, got)
+			}
+			if got := fmt.Sprint(result.TotalCount); got != test.total {
+				t.Errorf("total: expected: %s, got: %s", test.total, got)
+			}
+			if got := result.facet("brand"); got != test.brandFacet {
+				t.Errorf("brand facet: expected: %q, got: %q", test.brandFacet, got)
+			}
+			if got := result.facet("ram"); got != test.ramFacet {
+				t.Errorf("ram facet: expected: %q, got: %q", test.ramFacet, got)
+			}
+		})
+	}
+
+	t.Run("Invalid queries", func(t *testing.T) {
+		for _, query := range []url.Values{
+			{"limit": {"101"}},
+			{"min_price": {"-1"}},
+			{"min_price": {"30000"}, "max_price": {"20000"}},
+			{"q": {strings.Repeat("hp ", 100)}},
+		} {
+			if _, code := search(query); code != http.StatusUnprocessableEntity {
+				t.Errorf("%s: expected: %d, got: %d", query.Encode(), http.StatusUnprocessableEntity, code)
+			}
+		}
+	})
+}
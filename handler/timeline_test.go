@@ -0,0 +1,128 @@
+package handler
+
+import (
+	//Inbuild package(s)
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	//User defined package(s)
+	"online/models"
+)
+
+func TestGetOrderTimeline(t *testing.T) {
+	env := newTestEnv(t)
+	database, middleware := env.Handler, env.Middleware
+	other := createUser(t, env.Repo, "Surya", "surya@gmail.com", "user")
+	otherToken := createToken(t, env.Repo, other)
+	product := createProduct(t, env.Repo, "hp", "20000", "2GB", "2000")
+	e := newEcho()
+	e.POST("/orders", database.AddOrder, middleware.AuthMiddleware)
+	e.POST("/orders/:order_id/payments", database.Payment, middleware.AuthMiddleware)
+	e.PUT("/orders/:order_id/status", database.UpdateOrderStatusById, middleware.AuthMiddleware)
+	e.DELETE("/orders/:order_id", database.CancelOrderById, middleware.AuthMiddleware)
+	e.GET("/orders/:order_id/timeline", database.GetOrderTimeline, middleware.AuthMiddleware)
+
+	request := func(method, url, token, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, url, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		}
+		resp := httptest.NewRecorder()
+		e.ServeHTTP(resp, req)
+		return resp
+	}
+	//Events of the timeline as "from>to actor note"
+	timeline := func(orderId uint, token string) []string {
+		t.Helper()
+		resp := request(http.MethodGet, fmt.Sprintf("/orders/%d/timeline", orderId), token, "")
+		if resp.Code != http.StatusOK {
+			t.Fatalf("expected: %d, got: %d %s", http.StatusOK, resp.Code, resp.Body)
+		}
+		var body struct {
+			Timeline []struct {
+				From, To, Actor, Note string
+				At                    time.Time
+			}
+		}
+		if err := json.Unmarshal(resp.Body.Bytes(), &body); err != nil {
+			t.Fatalf("decode %s: %v", resp.Body, err)
+		}
+		var events []string
+		for index, event := range body.Timeline {
+			if event.At.IsZero() || index > 0 && event.At.Before(body.Timeline[index-1].At) {
+				t.Fatalf("expected the events in time order, got %s", resp.Body)
+			}
+			events = append(events, fmt.Sprintf("%s>%s %s %s", event.From, event.To, event.Actor, event.Note))
+		}
+		return events
+	}
+
+	resp := request(http.MethodPost, "/orders", env.UserToken, `{"brand_name":"hp","product_price":"20000","ram_capacity":"2GB","ram_price":"2000","name":"Hari","address":"5th street","phone_number":"9876543210"}`)
+	if resp.Code != http.StatusOK {
+		t.Fatalf("add order: %d %s", resp.Code, resp.Body)
+	}
+	//The status URL of the answer ends with the id of the new order
+	var placed map[string]interface{}
+	if err := json.Unmarshal(resp.Body.Bytes(), &placed); err != nil {
+		t.Fatalf("decode %s: %v", resp.Body, err)
+	}
+	statusURL, _ := placed["click here to get a order status"].(string)
+	var orderId uint
+	if _, err := fmt.Sscanf(statusURL, "/api/v1/orders/%d/status", &orderId); err != nil {
+		t.Fatalf("order id of %q: %v", statusURL, err)
+	}
+	url := fmt.Sprintf("/orders/%d", orderId)
+	request(http.MethodPost, url+"/payments", env.UserToken, `{"payment":"22000"}`)
+	request(http.MethodPut, url+"/status", env.AdminToken, `{"order_status":"packed","note":"2 boxes"}`)
+	//Refused moves are not recorded
+	if resp := request(http.MethodPut, url+"/status", env.AdminToken, `{"order_status":"delivered"}`); resp.Code != http.StatusConflict {
+		t.Fatalf("expected: %d, got: %d", http.StatusConflict, resp.Code)
+	}
+	request(http.MethodDelete, url, env.UserToken, "")
+
+	t.Run("Every change in order", func(t *testing.T) {
+		want := []string{
+			">pending_payment user order placed",
+			"pending_payment>paid user payment of 22000",
+			"paid>packed admin 2 boxes",
+			"packed>refunded user cancelled by the customer",
+		}
+		for _, token := range []string{env.UserToken, env.AdminToken} {
+			if got := timeline(orderId, token); strings.Join(got, "\n") != strings.Join(want, "\n") {
+				t.Fatalf("expected:\n%s\ngot:\n%s", strings.Join(want, "\n"), strings.Join(got, "\n"))
+			}
+		}
+	})
+
+	t.Run("Order of another user", func(t *testing.T) {
+		if want, got := http.StatusNotFound, request(http.MethodGet, url+"/timeline", otherToken, "").Code; want != got {
+			t.Fatalf("expected: %d, got: %d", want, got)
+		}
+	})
+
+	t.Run("Order not found", func(t *testing.T) {
+		if want, got := http.StatusNotFound, request(http.MethodGet, fmt.Sprintf("/orders/%d/timeline", MissingId), env.AdminToken, "").Code; want != got {
+			t.Fatalf("expected: %d, got: %d", want, got)
+		}
+	})
+
+	t.Run("Missing token", func(t *testing.T) {
+		if want, got := http.StatusBadRequest, request(http.MethodGet, url+"/timeline", "", "").Code; want != got {
+			t.Fatalf("expected: %d, got: %d", want, got)
+		}
+	})
+
+	t.Run("Moved by the fixtures", func(t *testing.T) {
+		shipped := moveOrder(t, env.Repo, createOrder(t, env.Repo, env.User, product),
+			models.OrderPaid, models.OrderPacked, models.OrderShipped)
+		if got := timeline(shipped.OrderId, env.UserToken); len(got) != 4 || got[3] != "packed>shipped admin " {
+			t.Fatalf("expected 4 events ending shipped, got %q", got)
+		}
+	})
+}
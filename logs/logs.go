@@ -1,19 +1,143 @@
-package logs
-
-import (
-	//user defined package
-	"online/models"
-
-	//inbuild package(s)
-	"log"
-	"os"
-)
-
-// Create a custom log
-func Log() (logger models.Logs) {
-	log.SetFlags(log.Ldate | log.Lmicroseconds | log.Lshortfile)
-	file, _ := os.OpenFile("log.log", os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
-	logger.Info = log.New(file, "[INFO:] ", log.Ldate|log.Lmicroseconds|log.Lshortfile)
-	logger.Error = log.New(file, "[ERROR:]", log.Ldate|log.Lmicroseconds|log.Lshortfile)
-	return
-}
+package logs
+
+import (
+	//user defined package
+	"online/config"
+
+	//inbuild package(s)
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	//Third party package(s)
+	"github.com/labstack/echo"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// Key of the request logger in the echo context
+const requestKey = "logger"
+
+var (
+	//The single logger of the process, replaced by Setup
+	logger = slog.New(slog.NewJSONHandler(os.Stderr, nil))
+
+	//Rotated log file opened by Setup, if any, and the stop of its timed rotation
+	mu           sync.Mutex
+	file         *lumberjack.Logger
+	stopRotation chan struct{}
+)
+
+// Write JSON log lines to the output of the configuration, at its level.
+// A log file is rotated once it reaches the maximum size and every rotate
+// interval; the rotated files are compressed and the oldest removed.
+func Setup(cfg config.Log) error {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(cfg.Level)); err != nil {
+		return err
+	}
+	var writer io.Writer
+	switch cfg.Output {
+	case "stdout":
+		writer = os.Stdout
+	case "stderr":
+		writer = os.Stderr
+	default:
+		path := cfg.Output
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(cfg.Dir, path)
+		}
+		//Fail at start-up on a log file that can't be written
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		probe, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			return err
+		}
+		probe.Close()
+		writer = &lumberjack.Logger{
+			Filename:   path,
+			MaxSize:    cfg.MaxSizeMB,
+			MaxBackups: cfg.MaxBackups,
+			MaxAge:     cfg.MaxAgeDays,
+			Compress:   cfg.Compress,
+			LocalTime:  true,
+		}
+	}
+	Close()
+	mu.Lock()
+	defer mu.Unlock()
+	logger = slog.New(slog.NewJSONHandler(writer, &slog.HandlerOptions{Level: level}))
+	if rotated, ok := writer.(*lumberjack.Logger); ok {
+		file = rotated
+		if cfg.RotateInterval > 0 {
+			stopRotation = make(chan struct{})
+			go rotateEvery(rotated, cfg.RotateInterval, stopRotation)
+		}
+	}
+	return nil
+}
+
+// Rotate the log file every interval until stop is closed
+func rotateEvery(file *lumberjack.Logger, interval time.Duration, stop chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := file.Rotate(); err != nil {
+				logger.Error("Error at rotating the log file", "error", err)
+			}
+		case <-stop:
+			return
+		}
+	}
+}
+
+// Reopen the log file, e.g. on SIGHUP after logrotate moved it away. The
+// next line is written to a new file at the configured path.
+func Reopen() error {
+	mu.Lock()
+	defer mu.Unlock()
+	if file == nil {
+		return nil
+	}
+	return file.Close()
+}
+
+// Stop the timed rotation and close the log file opened by Setup
+func Close() error {
+	mu.Lock()
+	defer mu.Unlock()
+	if stopRotation != nil {
+		close(stopRotation)
+		stopRotation = nil
+	}
+	if file == nil {
+		return nil
+	}
+	err := file.Close()
+	file = nil
+	return err
+}
+
+// Logger of the process, for logs outside of a request
+func Log() *slog.Logger {
+	return logger
+}
+
+// Logger of the request, carrying its request id, route and user id
+func Request(c echo.Context) *slog.Logger {
+	if log, ok := c.Get(requestKey).(*slog.Logger); ok {
+		return log
+	}
+	return logger
+}
+
+// Replace the logger of the request, e.g. to add the user id once known
+func SetRequest(c echo.Context, log *slog.Logger) {
+	c.Set(requestKey, log)
+}
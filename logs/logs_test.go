@@ -0,0 +1,123 @@
+package logs
+
+import (
+	//user defined package
+	"online/config"
+
+	//inbuild package(s)
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Rotation settings of a log file in dir
+func fileConfig(dir string) config.Log {
+	cfg := config.Default().Log
+	cfg.Dir, cfg.Output, cfg.RotateInterval = dir, "app.log", 0
+	return cfg
+}
+
+// Files of dir other than the current log file, once there are at least count
+// of them and all of them have the suffix. Rotated files are compressed and
+// pruned in the background, so the directory must stay that way for a moment.
+func waitForBackups(t *testing.T, dir string, count int, suffix string) []string {
+	t.Helper()
+	deadline := time.Now().Add(3 * time.Second)
+	settled := 0
+	for {
+		var backups []string
+		others := 0
+		entries, _ := os.ReadDir(dir)
+		for _, entry := range entries {
+			if entry.Name() == "app.log" {
+				continue
+			}
+			if strings.HasSuffix(entry.Name(), suffix) {
+				backups = append(backups, entry.Name())
+			} else {
+				others++
+			}
+		}
+		if len(backups) >= count && others == 0 {
+			settled++
+		} else {
+			settled = 0
+		}
+		if settled == 3 || time.Now().After(deadline) {
+			return backups
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func TestRotateBySize(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "logs")
+	cfg := fileConfig(dir)
+	cfg.MaxSizeMB, cfg.MaxBackups = 1, 2
+	if err := Setup(cfg); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { Close() })
+
+	//4 MB of lines rotate the 1 MB file at least 3 times
+	line := strings.Repeat("x", 1024)
+	for i := 0; i < 4*1024; i++ {
+		Log().Info(line)
+	}
+	if backups := waitForBackups(t, dir, cfg.MaxBackups, ".gz"); len(backups) != cfg.MaxBackups {
+		t.Fatalf("expected %d compressed backups, got %v", cfg.MaxBackups, backups)
+	}
+}
+
+func TestRotateByInterval(t *testing.T) {
+	dir := t.TempDir()
+	cfg := fileConfig(dir)
+	cfg.RotateInterval, cfg.Compress = 50*time.Millisecond, false
+	if err := Setup(cfg); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { Close() })
+
+	Log().Info("before the rotation")
+	if backups := waitForBackups(t, dir, 1, ".log"); len(backups) == 0 {
+		t.Fatal("expected the log file to be rotated after the interval")
+	}
+}
+
+func TestReopen(t *testing.T) {
+	dir := t.TempDir()
+	if err := Setup(fileConfig(dir)); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { Close() })
+	Log().Info("first")
+
+	//What logrotate does before sending SIGHUP
+	path := filepath.Join(dir, "app.log")
+	if err := os.Rename(path, path+".1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := Reopen(); err != nil {
+		t.Fatal(err)
+	}
+	Log().Info("second")
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("expected a new log file: %v", err)
+	}
+	if !strings.Contains(string(content), "second") || strings.Contains(string(content), "first") {
+		t.Fatalf("expected only the lines after reopening, got %s", content)
+	}
+}
+
+func TestSetupUnwritableFile(t *testing.T) {
+	cfg := fileConfig(t.TempDir())
+	cfg.Output = filepath.Join(cfg.Dir, "missing", "\x00", "app.log")
+	if err := Setup(cfg); err == nil {
+		Close()
+		t.Fatal("expected an error for a log file that can't be created")
+	}
+}
@@ -1,36 +1,154 @@
-package main
-
-import (
-	//user defined package(s)
-	"online/Lookup"
-	"online/driver"
-	"online/logs"
-	"online/router"
-
-	//Third party package(s)
-	"github.com/labstack/echo"
-)
-
-func main() {
-	log := logs.Log()
-	echo := echo.New()
-
-	//Establishing a DB-connection
-	Db := driver.DbConnection()
-
-	//Checking for a database updates
-	Lookup.UpdateDatabase(Db)
-
-	//Routing all the handlers
-	router.LoginHandlers(Db, echo)
-	router.AdminHandlers(Db, echo)
-	router.UserHandlers(Db, echo)
-	router.CommonHandlers(Db, echo)
-
-	//Start a server
-	log.Info.Println("Message : 'Server starts in port 8000...' Status : 200")
-	if err := echo.Start(":8000"); err != nil {
-		log.Info.Println("Message : 'Error at start a server...' Status : 500")
-		return
-	}
-}
+package main
+
+import (
+	//user defined package(s)
+	"online/Lookup"
+	"online/apierror"
+	"online/config"
+	"online/driver"
+	"online/handler"
+	"online/logs"
+	"online/metrics"
+	"online/middleware"
+	"online/repository"
+	"online/router"
+	"online/tracing"
+	"online/validation"
+
+	//Inbuild package(s)
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	//Third party package(s)
+	"github.com/labstack/echo"
+)
+
+func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "migrate":
+			os.Exit(migrate(os.Args[2:]))
+		case "seed":
+			os.Exit(seedDatabase(os.Args[2:]))
+		}
+	}
+	os.Exit(run(os.Args[1:]))
+}
+
+// Server, returns the exit code once the deferred clean-ups (log file, spans,
+// connection pool) ran
+func run(args []string) int {
+	flags := flag.NewFlagSet("online", flag.ExitOnError)
+	autoMigrate := flags.Bool("auto-migrate", true, "apply the pending database migrations on start")
+
+	config.RegisterFlags(flags)
+	flags.Parse(args)
+
+	//Loading and validating the configuration
+	cfg, err := config.Load(flags)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error : invalid configuration\n%s\n", err)
+		return 2
+	}
+	if err := logs.Setup(cfg.Log); err != nil {
+		fmt.Fprintln(os.Stderr, "Error : log output:", err)
+		return 2
+	}
+	defer logs.Close()
+
+	//Reopening the log file on SIGHUP, after logrotate moved it away
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	defer signal.Stop(hangup)
+	go func() {
+		for range hangup {
+			if err := logs.Reopen(); err != nil {
+				logs.Log().Error("Error at reopening the log file", "error", err)
+			}
+		}
+	}()
+
+	log := logs.Log()
+
+	//Exporting the spans of the requests and queries, when an endpoint is set
+	shutdownTracing, err := tracing.Setup(cfg.Tracing)
+	if err != nil {
+		log.Error("Error at setting up tracing", "error", err)
+		return 1
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			log.Error("Error at flushing the spans", "error", err)
+		}
+	}()
+
+	echo := echo.New()
+	echo.Server.ReadTimeout = cfg.Server.ReadTimeout
+	echo.Server.WriteTimeout = cfg.Server.WriteTimeout
+	echo.Server.IdleTimeout = cfg.Server.IdleTimeout
+	//Every error answered in the same JSON envelope, and the request bodies
+	//checked against their 'validate' tags
+	echo.HTTPErrorHandler = apierror.Handler
+	echo.Validator = validation.New()
+
+	//Establishing a DB-connection, shared by every handler
+	Db, err := driver.DbConnection(cfg.Database)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error :", err)
+		return 1
+	}
+	defer driver.Close(Db)
+	if err := Db.Use(tracing.GormPlugin{}); err != nil {
+		log.Error("Error at tracing the database queries", "error", err)
+	}
+
+	//Applying the pending database migrations
+	if *autoMigrate {
+		if err := Lookup.UpdateDatabase(Db); err != nil {
+			log.Error("Error at applying the migrations", "error", err)
+			return 1
+		}
+	}
+
+	//Request id, span, metrics and access log of every request
+	echo.Use(middleware.RequestID, tracing.Middleware, metrics.Middleware, middleware.AccessLog)
+	if err := metrics.RegisterDB(Db, cfg.Database.Name); err != nil {
+		log.Error("Error at registering the database metrics", "error", err)
+	}
+
+	//Routing all the handlers
+	router.HealthHandlers(handler.Health{Db: Db}, echo)
+	router.MetricsHandlers(echo)
+	router.DocsHandlers(echo)
+	handler := handler.New(repository.NewGormRepository(Db), cfg.JWT)
+	router.APIHandlers(handler, echo)
+	router.LoginHandlers(handler, echo)
+	router.AdminHandlers(handler, echo)
+	router.UserHandlers(handler, echo)
+	router.CommonHandlers(handler, echo)
+
+	//Start a server, until SIGINT or SIGTERM
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		//A second signal kills the server without waiting
+		<-ctx.Done()
+		stop()
+	}()
+	log.Info("Server starts", "addr", cfg.Server.Addr)
+	if err := serve(ctx, echo, cfg.Server.Addr, cfg.Server.ShutdownTimeout); err != nil {
+		log.Error("Server stopped with an error", "error", err)
+		return 1
+	}
+
+	//Closing the DB-connection pool (deferred above) once no request uses it
+	log.Info("Server stopped")
+	return 0
+}
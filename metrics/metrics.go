@@ -0,0 +1,105 @@
+package metrics
+
+import (
+	//user defined package(s)
+	"online/apierror"
+
+	//Inbuild package(s)
+	"strconv"
+	"time"
+
+	//Third party package(s)
+	"github.com/labstack/echo"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"gorm.io/gorm"
+)
+
+// Registry of every metric exposed on /metrics
+var Registry = prometheus.NewRegistry()
+
+// HTTP metrics, labelled by route pattern (not path, to bound the number of series)
+var (
+	requests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "HTTP requests answered, by method, route and status.",
+	}, []string{"method", "route", "status"})
+
+	latency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Time taken to answer HTTP requests, by method, route and status.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+)
+
+// Business metrics
+var (
+	Signups = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "signups_total",
+		Help: "Users signed up.",
+	})
+
+	LoginFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "login_failures_total",
+		Help: "Failed logins, by reason.",
+	}, []string{"reason"})
+
+	OrdersPlaced = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "orders_placed_total",
+		Help: "Orders placed.",
+	})
+
+	OrdersCancelled = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "order_cancellations_total",
+		Help: "Orders cancelled by their user.",
+	})
+
+	Payments = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "payments_total",
+		Help: "Payment attempts, by result (succeeded or failed).",
+	}, []string{"result"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		requests, latency,
+		Signups, LoginFailures, OrdersPlaced, OrdersCancelled, Payments,
+	)
+}
+
+// Expose the connection pool statistics of the database
+func RegisterDB(Db *gorm.DB, name string) error {
+	sqlDb, err := Db.DB()
+	if err != nil {
+		return err
+	}
+	return Registry.Register(collectors.NewDBStatsCollector(sqlDb, name))
+}
+
+// Count and time every request
+func Middleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		start := time.Now()
+		status := apierror.Serve(c, next)
+		route := c.Path()
+		if route == "" {
+			route = "unmatched"
+		}
+		labels := prometheus.Labels{
+			"method": c.Request().Method,
+			"route":  route,
+			"status": strconv.Itoa(status),
+		}
+		requests.With(labels).Inc()
+		latency.With(labels).Observe(time.Since(start).Seconds())
+		return nil
+	}
+}
+
+// Handler of /metrics in the Prometheus text format
+func Handler() echo.HandlerFunc {
+	return echo.WrapHandler(promhttp.HandlerFor(Registry, promhttp.HandlerOpts{}))
+}
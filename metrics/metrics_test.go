@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	//Inbuild package(s)
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	//Third party package(s)
+	"github.com/glebarez/sqlite"
+	"github.com/labstack/echo"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestMiddleware(t *testing.T) {
+	e := echo.New()
+	e.Use(Middleware)
+	e.GET("/orders/:order_id", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, map[string]interface{}{"status": 200})
+	})
+	e.GET("/missing", func(c echo.Context) error { return echo.ErrNotFound })
+
+	ok := requests.WithLabelValues(http.MethodGet, "/orders/:order_id", "200")
+	missing := requests.WithLabelValues(http.MethodGet, "/missing", "404")
+	okBefore, missingBefore := testutil.ToFloat64(ok), testutil.ToFloat64(missing)
+
+	for _, path := range []string{"/orders/1", "/orders/2", "/missing"} {
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+	//Requests are counted by route, not by path
+	if got := testutil.ToFloat64(ok) - okBefore; got != 2 {
+		t.Errorf("expected 2 requests on the order route, got %v", got)
+	}
+	if got := testutil.ToFloat64(missing) - missingBefore; got != 1 {
+		t.Errorf("expected the handler error to be counted as a 404, got %v", got)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	Db, err := gorm.Open(sqlite.Open("file:"+t.Name()+"?mode=memory&cache=shared"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDb, _ := Db.DB()
+	t.Cleanup(func() { sqlDb.Close() })
+	if err := RegisterDB(Db, "metrics_test"); err != nil {
+		t.Fatal(err)
+	}
+	//A collector is told apart by its metrics, so an equal one unregisters it
+	//and the test can run again (-count) on the global registry
+	t.Cleanup(func() { Registry.Unregister(collectors.NewDBStatsCollector(sqlDb, "metrics_test")) })
+	Signups.Inc()
+
+	e := echo.New()
+	e.GET("/metrics", Handler())
+	resp := httptest.NewRecorder()
+	e.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if resp.Code != http.StatusOK {
+		t.Fatalf("expected: %d, got: %d", http.StatusOK, resp.Code)
+	}
+	for _, name := range []string{"signups_total", "go_goroutines", `go_sql_open_connections{db_name="metrics_test"}`} {
+		if !strings.Contains(resp.Body.String(), name) {
+			t.Errorf("expected %s in the metrics", name)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	//Inbuild packages
+	"strconv"
+	"strings"
+	"time"
+
+	//Third-party packages
+	"github.com/labstack/echo"
+)
+
+// Mark the responses of the old routes as deprecated since the given time
+// (Deprecation header, RFC 9745), with a link to the route replacing each one.
+// successors maps "METHOD /old/:param" to "METHOD /new/:param"; the params of
+// the link are filled from the request.
+func Deprecated(since time.Time, successors map[string]string) echo.MiddlewareFunc {
+	deprecation := "@" + strconv.FormatInt(since.Unix(), 10)
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			header := c.Response().Header()
+			header.Set("Deprecation", deprecation)
+			if successor, ok := successors[c.Request().Method+" "+c.Path()]; ok {
+				_, link, _ := strings.Cut(successor, " ")
+				for _, name := range c.ParamNames() {
+					link = strings.Replace(link, ":"+name, c.Param(name), 1)
+				}
+				header.Set("Link", "<"+link+`>; rel="successor-version"`)
+			}
+			return next(c)
+		}
+	}
+}
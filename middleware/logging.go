@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	//User-defined packages
+	"online/apierror"
+	"online/logs"
+
+	//Inbuild packages
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"regexp"
+	"time"
+
+	//Third-party packages
+	"github.com/labstack/echo"
+)
+
+// Header carrying the id of a request, from the client or generated
+const RequestIDHeader = "X-Request-ID"
+
+// Request ids accepted from clients, anything else is replaced
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// Give every request an id, returned in the 'X-Request-ID' header, and a
+// logger carrying that id, the method and the route
+func RequestID(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id := c.Request().Header.Get(RequestIDHeader)
+		if !validRequestID.MatchString(id) {
+			id = newRequestID()
+		}
+		c.Response().Header().Set(RequestIDHeader, id)
+		logs.SetRequest(c, logs.Log().With(
+			"request_id", id,
+			"method", c.Request().Method,
+			"route", c.Path(),
+		))
+		return next(c)
+	}
+}
+
+// Log one line per request with its status and latency, once it is answered
+func AccessLog(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		start := time.Now()
+		status := apierror.Serve(c, next)
+		level := slog.LevelInfo
+		if status >= 500 {
+			level = slog.LevelError
+		} else if status >= 400 {
+			level = slog.LevelWarn
+		}
+		logs.Request(c).Log(c.Request().Context(), level, "request",
+			"status", status,
+			"latency_ms", float64(time.Since(start).Microseconds())/1000,
+			"path", c.Request().URL.Path,
+			"remote_ip", c.RealIP(),
+			"bytes", c.Response().Size,
+		)
+		return nil
+	}
+}
+
+// Random 16 byte request id
+func newRequestID() string {
+	id := make([]byte, 16)
+	rand.Read(id)
+	return hex.EncodeToString(id)
+}
@@ -0,0 +1,112 @@
+package middleware
+
+import (
+	//User-defined packages
+	"online/config"
+	"online/logs"
+	"online/models"
+
+	//Inbuild packages
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	//Third-party packages
+	"github.com/labstack/echo"
+)
+
+// Send the logs to a file of the test and return a reader of its JSON lines
+func captureLogs(t *testing.T) func() []map[string]interface{} {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "test.log")
+	if err := logs.Setup(config.Log{Level: "debug", Output: file}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { logs.Close() })
+	return func() (lines []map[string]interface{}) {
+		content, err := os.Open(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer content.Close()
+		scanner := bufio.NewScanner(content)
+		for scanner.Scan() {
+			var line map[string]interface{}
+			if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+				t.Fatalf("log line %q is not JSON: %v", scanner.Text(), err)
+			}
+			lines = append(lines, line)
+		}
+		return lines
+	}
+}
+
+func TestRequestLogging(t *testing.T) {
+	readLogs := captureLogs(t)
+	auth := Database{JWT: config.JWT{Secret: "test-secret", Expiry: time.Hour}}
+	token, err := auth.CreateToken(models.User{UserId: 7, RoleId: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e := echo.New()
+	e.Use(RequestID, AccessLog)
+	e.GET("/orders/:order_id", func(c echo.Context) error {
+		logs.Request(c).Info("inside the handler")
+		return c.JSON(http.StatusNotFound, map[string]interface{}{"status": 404})
+	}, auth.AuthMiddleware)
+
+	req := httptest.NewRequest(http.MethodGet, "/orders/3", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set(RequestIDHeader, "client-id.1")
+	resp := httptest.NewRecorder()
+	e.ServeHTTP(resp, req)
+	if id := resp.Header().Get(RequestIDHeader); id != "client-id.1" {
+		t.Fatalf("expected the request id of the client, got %q", id)
+	}
+
+	lines := readLogs()
+	if len(lines) != 2 {
+		t.Fatalf("expected a handler line and an access line, got %v", lines)
+	}
+	for _, line := range lines {
+		if line["request_id"] != "client-id.1" || line["route"] != "/orders/:order_id" || line["user_id"] != "7" {
+			t.Errorf("expected the request id, route and user id on every line, got %v", line)
+		}
+	}
+	access := lines[1]
+	if access["msg"] != "request" || access["status"] != 404.0 || access["level"] != "WARN" {
+		t.Errorf("unexpected access line %v", access)
+	}
+	if _, ok := access["latency_ms"].(float64); !ok {
+		t.Errorf("expected the latency on the access line, got %v", access)
+	}
+}
+
+func TestGeneratedRequestID(t *testing.T) {
+	captureLogs(t)
+	e := echo.New()
+	e.Use(RequestID, AccessLog)
+	e.GET("/", func(c echo.Context) error { return echo.ErrNotFound })
+
+	ids := map[string]bool{}
+	for _, given := range []string{"", "not a valid id!"} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(RequestIDHeader, given)
+		resp := httptest.NewRecorder()
+		e.ServeHTTP(resp, req)
+		id := resp.Header().Get(RequestIDHeader)
+		if !validRequestID.MatchString(id) || id == given || ids[id] {
+			t.Fatalf("expected a new unique request id instead of %q, got %q", given, id)
+		}
+		ids[id] = true
+		if resp.Code != http.StatusNotFound {
+			t.Fatalf("expected the status of the handler error, got %d", resp.Code)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	//Inbuild packages
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	//Third-party packages
+	"github.com/labstack/echo"
+)
+
+func TestCan(t *testing.T) {
+	tests := []struct {
+		role       interface{}
+		permission Permission
+		want       bool
+	}{
+		{"admin", WriteProducts, true},
+		{"admin", WriteOrders, false},
+		{"admin", ReadAllOrders, true},
+		{"user", ReadProducts, true},
+		{"user", WriteProducts, false},
+		{"user", WriteOrders, true},
+		{"user", ReadAllOrderStatus, false},
+		//No token checked, or an unknown role
+		{nil, ReadProducts, false},
+		{"guest", ReadProducts, false},
+	}
+	for _, test := range tests {
+		c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder())
+		if test.role != nil {
+			c.Set("role", test.role)
+		}
+		if got := Can(c, test.permission); got != test.want {
+			t.Errorf("%v %s: expected %v, got %v", test.role, test.permission, test.want, got)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package main
+
+import (
+	//user defined package(s)
+	"online/Lookup"
+	"online/config"
+	"online/driver"
+	"online/logs"
+
+	//Inbuild package(s)
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	//Third party package(s)
+	"gorm.io/gorm"
+)
+
+const migrateUsage = `Usage: online migrate [--dry-run] [--database main|test] <command>
+
+Commands:
+  status         list the migrations and whether they are applied
+  up [N]         apply all (or the next N) pending migrations
+  down [N]       roll back the latest (or the latest N) applied migrations
+  redo           roll back the latest applied migration and apply it again
+  create <name>  create empty up/down files of a new migration in ./dbUpdates
+
+Flags:
+`
+
+// Migration subcommand, returns the exit code
+func migrate(args []string) int {
+	flags := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	dryRun := flags.Bool("dry-run", false, "print the SQL that would run without running it")
+	database := flags.String("database", "main", "database to migrate: 'main' (DB_NAME) or 'test' (TEST_DBNAME)")
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), migrateUsage)
+		flags.PrintDefaults()
+	}
+	config.RegisterFlags(flags)
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	cfg, err := config.Parse(flags)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error :", err)
+		return 2
+	}
+	if err := logs.Setup(cfg.Log); err != nil {
+		fmt.Fprintln(os.Stderr, "Error : log output:", err)
+		return 2
+	}
+	defer logs.Close()
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return 2
+	}
+	command, args := flags.Arg(0), flags.Args()[1:]
+
+	//Creating files doesn't need a database
+	if command == "create" {
+		if len(args) != 1 {
+			flags.Usage()
+			return 2
+		}
+		files, err := Lookup.Create("dbUpdates", args[0])
+		for _, file := range files {
+			fmt.Println("created", file)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error :", err)
+			return 1
+		}
+		return 0
+	}
+
+	switch command {
+	case "status", "up", "down", "redo":
+	default:
+		flags.Usage()
+		return 2
+	}
+	limit := 0
+	if len(args) > 0 {
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n <= 0 || (command != "up" && command != "down") {
+			flags.Usage()
+			return 2
+		}
+		limit = n
+	}
+
+	//Every migration runs on, and is recorded in, the selected database
+	Db, err := connectDatabase(cfg.Database, *database)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error :", err)
+		return 1
+	}
+	defer driver.Close(Db)
+	switch command {
+	case "status":
+		err = printStatus(Db)
+	case "up":
+		if *dryRun {
+			migrations, e := Lookup.Pending(Db, limit)
+			printSQL(migrations, "up")
+			err = e
+			break
+		}
+		migrations, e := Lookup.Up(Db, limit)
+		printDone(migrations, "applied")
+		err = e
+	case "down":
+		if *dryRun {
+			migrations, e := Lookup.Rollbacks(Db, limit)
+			printSQL(migrations, "down")
+			err = e
+			break
+		}
+		migrations, e := Lookup.Down(Db, limit)
+		printDone(migrations, "rolled back")
+		err = e
+	case "redo":
+		if *dryRun {
+			migrations, e := Lookup.Rollbacks(Db, 1)
+			printSQL(migrations, "down")
+			printSQL(migrations, "up")
+			err = e
+			break
+		}
+		migrations, e := Lookup.Redo(Db)
+		printDone(migrations, "redone")
+		err = e
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error :", err)
+		return 1
+	}
+	return 0
+}
+
+// Connect to the database selected by a '--database' flag
+func connectDatabase(cfg config.Database, name string) (*gorm.DB, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid database configuration\n%w", err)
+	}
+	switch name {
+	case "main":
+		return driver.DbConnection(cfg)
+	case "test":
+		if cfg.TestName == "" {
+			return nil, fmt.Errorf("TEST_DBNAME is required for the test database")
+		}
+		return driver.TestDbConnection(cfg)
+	}
+	return nil, fmt.Errorf("unknown database %q", name)
+}
+
+// Print every migration with its state
+func printStatus(Db *gorm.DB) error {
+	statuses, err := Lookup.Status(Db)
+	if err != nil {
+		return err
+	}
+	writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(writer, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+	for _, status := range statuses {
+		if status.Applied {
+			fmt.Fprintf(writer, "%04d\t%s\tapplied\t%s\n", status.Version, status.Name, status.AppliedAt.Format(time.RFC3339))
+		} else {
+			fmt.Fprintf(writer, "%04d\t%s\tpending\t-\n", status.Version, status.Name)
+		}
+	}
+	return writer.Flush()
+}
+
+// Print the SQL of the migrations in the given direction
+func printSQL(migrations []Lookup.Migration, direction string) {
+	for _, migration := range migrations {
+		fmt.Printf("-- %s (%s)\n", migration.File(), direction)
+		if direction == "up" {
+			fmt.Println(migration.Up)
+		} else {
+			fmt.Println(migration.Down)
+		}
+	}
+}
+
+// Print the migrations that were run
+func printDone(migrations []Lookup.Migration, action string) {
+	if len(migrations) == 0 {
+		fmt.Println("nothing to do")
+	}
+	for _, migration := range migrations {
+		fmt.Println(action, migration.File())
+	}
+}
@@ -0,0 +1,103 @@
+package models
+
+import (
+	//Inbuild package(s)
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// State of an order. An order starts waiting for its payment and only moves
+// along orderTransitions, checked by Transition.
+type OrderState string
+
+const (
+	OrderPendingPayment OrderState = "pending_payment"
+	OrderPaid           OrderState = "paid"
+	OrderPacked         OrderState = "packed"
+	OrderShipped        OrderState = "shipped"
+	OrderDelivered      OrderState = "delivered"
+	//Cancelled before the payment
+	OrderCancelled OrderState = "cancelled"
+	//Paid back, after a cancel or a return
+	OrderRefunded OrderState = "refunded"
+	//Sent back by the customer after the delivery
+	OrderReturned OrderState = "returned"
+)
+
+// States an order may go to from each state. Cancelled and refunded orders
+// are final.
+var orderTransitions = map[OrderState][]OrderState{
+	OrderPendingPayment: {OrderPaid, OrderCancelled},
+	OrderPaid:           {OrderPacked, OrderRefunded},
+	OrderPacked:         {OrderShipped, OrderRefunded},
+	OrderShipped:        {OrderDelivered},
+	OrderDelivered:      {OrderReturned},
+	OrderReturned:       {OrderRefunded},
+	OrderCancelled:      {},
+	OrderRefunded:       {},
+}
+
+// Every state, in the order of the transitions
+var OrderStates = []OrderState{
+	OrderPendingPayment, OrderPaid, OrderPacked, OrderShipped, OrderDelivered,
+	OrderCancelled, OrderRefunded, OrderReturned,
+}
+
+// Move from the state s to next not allowed by the state machine
+type TransitionError struct {
+	From, To OrderState
+}
+
+func (e *TransitionError) Error() string {
+	next := e.From.Next()
+	if len(next) == 0 {
+		return fmt.Sprintf("order is %s, it cannot change anymore", e.From)
+	}
+	names := make([]string, len(next))
+	for index, state := range next {
+		names[index] = string(state)
+	}
+	return fmt.Sprintf("order is %s, it cannot become %s (only %s)", e.From, e.To, strings.Join(names, ", "))
+}
+
+// Tell if an order in the state s may go to next
+func (s OrderState) CanBecome(next OrderState) bool {
+	for _, state := range orderTransitions[s] {
+		if state == next {
+			return true
+		}
+	}
+	return false
+}
+
+// States the order may go to from s, sorted
+func (s OrderState) Next() []OrderState {
+	next := append([]OrderState(nil), orderTransitions[s]...)
+	sort.Slice(next, func(i, j int) bool { return next[i] < next[j] })
+	return next
+}
+
+// Payment of an order in the state s: pending, paid, refunded or none for an
+// order cancelled before its payment
+func (s OrderState) PaymentStatus() string {
+	switch s {
+	case OrderPendingPayment:
+		return "pending"
+	case OrderCancelled:
+		return "none"
+	case OrderRefunded:
+		return "refunded"
+	}
+	return "paid"
+}
+
+// Move the order to the state next, with its payment status, or tell why it
+// cannot. This is the only place the state of an order changes.
+func (o *OrderProductInfo) Transition(next OrderState) error {
+	if !o.Status.CanBecome(next) {
+		return &TransitionError{From: o.Status, To: next}
+	}
+	o.Status, o.PaymentStatus = next, next.PaymentStatus()
+	return nil
+}
@@ -0,0 +1,69 @@
+package models
+
+import (
+	//Inbuild package(s)
+	"errors"
+	"testing"
+)
+
+func TestTransition(t *testing.T) {
+	tests := []struct {
+		from, to OrderState
+		allowed  bool
+	}{
+		{OrderPendingPayment, OrderPaid, true},
+		{OrderPendingPayment, OrderCancelled, true},
+		{OrderPendingPayment, OrderShipped, false},
+		{OrderPaid, OrderPaid, false},
+		{OrderPaid, OrderPacked, true},
+		{OrderPaid, OrderRefunded, true},
+		{OrderPaid, OrderCancelled, false},
+		{OrderPacked, OrderShipped, true},
+		{OrderShipped, OrderRefunded, false},
+		{OrderShipped, OrderDelivered, true},
+		{OrderDelivered, OrderReturned, true},
+		{OrderReturned, OrderRefunded, true},
+		{OrderCancelled, OrderPaid, false},
+		{OrderRefunded, OrderPaid, false},
+		{"order confirmed", OrderPacked, false},
+	}
+	for _, test := range tests {
+		order := OrderProductInfo{Status: test.from, PaymentStatus: test.from.PaymentStatus()}
+		err := order.Transition(test.to)
+		if allowed := err == nil; allowed != test.allowed {
+			t.Errorf("%s -> %s: expected allowed %v, got %v", test.from, test.to, test.allowed, err)
+			continue
+		}
+		var transitionErr *TransitionError
+		switch {
+		case test.allowed && (order.Status != test.to || order.PaymentStatus != test.to.PaymentStatus()):
+			t.Errorf("%s -> %s: got state %s and payment %s", test.from, test.to, order.Status, order.PaymentStatus)
+		case !test.allowed && (order.Status != test.from || !errors.As(err, &transitionErr)):
+			t.Errorf("%s -> %s: the order changed or the error is %v", test.from, test.to, err)
+		}
+	}
+}
+
+// Every state is reachable from a new order
+func TestStatesReachable(t *testing.T) {
+	reached := map[OrderState]bool{OrderPendingPayment: true}
+	queue := []OrderState{OrderPendingPayment}
+	for len(queue) > 0 {
+		state := queue[0]
+		queue = queue[1:]
+		for _, next := range state.Next() {
+			if !reached[next] {
+				reached[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+	for _, state := range OrderStates {
+		if !reached[state] {
+			t.Errorf("%s cannot be reached", state)
+		}
+	}
+	if len(reached) != len(OrderStates) {
+		t.Errorf("expected %d states, reached %d", len(OrderStates), len(reached))
+	}
+}
@@ -0,0 +1,42 @@
+package openapi
+
+import (
+	//Inbuild package(s)
+	"fmt"
+	"html"
+	"net/http"
+
+	//Third party package(s)
+	"github.com/labstack/echo"
+)
+
+// Serve the document as JSON
+func Handler(doc Document) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return c.JSON(http.StatusOK, doc)
+	}
+}
+
+// Swagger UI page of the document served at specURL. The page loads the UI
+// from the unpkg CDN, so the browser needs access to it.
+func UI(title, specURL string) echo.HandlerFunc {
+	page := fmt.Sprintf(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>%s</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+  <script>
+    window.ui = SwaggerUIBundle({url: %q, dom_id: "#swagger-ui"});
+  </script>
+</body>
+</html>
+`, html.EscapeString(title), specURL)
+	return func(c echo.Context) error {
+		return c.HTML(http.StatusOK, page)
+	}
+}
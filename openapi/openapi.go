@@ -0,0 +1,313 @@
+package openapi
+
+import (
+	//user defined package(s)
+	"online/apierror"
+
+	//Inbuild package(s)
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	//Third party package(s)
+	"github.com/labstack/echo"
+)
+
+// JSON schema, written as is in the document
+type Schema map[string]interface{}
+
+// Schemas of the plain fields of a response (status, message...)
+var (
+	Integer = Schema{"type": "integer"}
+	String  = Schema{"type": "string"}
+)
+
+// Operation of the API, as registered on echo (e.g. GET /orders/:order_id)
+type Route struct {
+	Method  string
+	Path    string
+	Tag     string
+	Summary string
+
+	//Route protected by the auth middleware, and the permission it requires
+	Auth       bool
+	Permission string
+
+	//Old route, replaced by another one
+	Deprecated bool
+
+	//Request body, a dto value whose 'json' and 'validate' tags give the schema
+	Request interface{}
+
+	//Query parameters, a dto value read from its 'query' tags
+	Query interface{}
+
+	//Fields of the response body, each either a value whose type gives the
+	//schema or a Schema. Other content types than JSON are given by
+	//ContentType, with a nil Response.
+	Response    map[string]interface{}
+	ContentType string
+
+	//Error statuses answered besides the ones implied by Auth and Request
+	Errors []int
+}
+
+// Key of the route in the document, and in the routes of echo
+func (r Route) Key() string {
+	return r.Method + " " + r.Path
+}
+
+// Document describing every route, built by Build
+type Document struct {
+	OpenAPI    string                            `json:"openapi"`
+	Info       Info                              `json:"info"`
+	Paths      map[string]map[string]interface{} `json:"paths"`
+	Components map[string]interface{}            `json:"components"`
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// Path parameters of echo (':order_id')
+var pathParam = regexp.MustCompile(`:(\w+)`)
+
+// Build the OpenAPI 3 document of the routes
+func Build(info Info, routes []Route) Document {
+	doc := Document{
+		OpenAPI: "3.0.3",
+		Info:    info,
+		Paths:   map[string]map[string]interface{}{},
+	}
+	schemas := map[string]interface{}{}
+	errorEnvelope := Schema{
+		"type":       "object",
+		"properties": Schema{"error": schemaOf(reflect.TypeOf(apierror.Error{}), schemas)},
+		"required":   []string{"error"},
+	}
+	schemas["ErrorEnvelope"] = errorEnvelope
+
+	for _, route := range routes {
+		path := pathParam.ReplaceAllString(route.Path, "{$1}")
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = map[string]interface{}{}
+		}
+		doc.Paths[path][strings.ToLower(route.Method)] = operation(route, schemas)
+	}
+	doc.Components = map[string]interface{}{
+		"schemas": schemas,
+		"securitySchemes": Schema{
+			"bearerAuth": Schema{"type": "http", "scheme": "bearer", "bearerFormat": "JWT"},
+		},
+	}
+	return doc
+}
+
+// Operation object of a route
+func operation(route Route, schemas map[string]interface{}) Schema {
+	op := Schema{
+		"summary":     route.Summary,
+		"operationId": operationId(route),
+		"tags":        []string{route.Tag},
+	}
+	var params []Schema
+	for _, match := range pathParam.FindAllStringSubmatch(route.Path, -1) {
+		params = append(params, Schema{
+			"name": match[1], "in": "path", "required": true,
+			"schema": Schema{"type": "integer", "minimum": 1},
+		})
+	}
+	errors := append([]int(nil), route.Errors...)
+	if route.Query != nil {
+		params = append(params, queryParams(reflect.TypeOf(route.Query), schemas)...)
+		errors = append(errors, http.StatusBadRequest, http.StatusUnprocessableEntity)
+	}
+	if params != nil {
+		op["parameters"] = params
+	}
+
+	if route.Request != nil {
+		op["requestBody"] = Schema{
+			"required": true,
+			"content":  Schema{echo.MIMEApplicationJSON: Schema{"schema": schemaOf(reflect.TypeOf(route.Request), schemas)}},
+		}
+		errors = append(errors, http.StatusBadRequest, http.StatusUnprocessableEntity)
+	}
+	if route.Auth {
+		op["security"] = []Schema{{"bearerAuth": []string{}}}
+		errors = append(errors, http.StatusBadRequest, http.StatusUnauthorized)
+		if route.Permission != "" {
+			op["description"] = "Requires the " + route.Permission + " permission."
+		}
+	}
+	if route.Deprecated {
+		op["deprecated"] = true
+	}
+
+	//Every operation may fail unexpectedly
+	errors = append(errors, http.StatusInternalServerError)
+	responses := Schema{"200": success(route, schemas)}
+	for _, status := range errors {
+		responses[strconv.Itoa(status)] = Schema{
+			"description": http.StatusText(status),
+			"content":     Schema{echo.MIMEApplicationJSON: Schema{"schema": Schema{"$ref": "#/components/schemas/ErrorEnvelope"}}},
+		}
+	}
+	op["responses"] = responses
+	return op
+}
+
+// Response of a successful request
+func success(route Route, schemas map[string]interface{}) Schema {
+	if route.Response == nil {
+		contentType := route.ContentType
+		if contentType == "" {
+			contentType = echo.MIMEApplicationJSON
+		}
+		schema := Schema{"type": "string"}
+		if contentType == echo.MIMEApplicationJSON {
+			schema = Schema{"type": "object"}
+		}
+		return Schema{"description": "OK", "content": Schema{contentType: Schema{"schema": schema}}}
+	}
+	properties := Schema{}
+	for name, value := range route.Response {
+		if schema, ok := value.(Schema); ok {
+			properties[name] = schema
+		} else {
+			properties[name] = schemaOf(reflect.TypeOf(value), schemas)
+		}
+	}
+	return Schema{
+		"description": "OK",
+		"content":     Schema{echo.MIMEApplicationJSON: Schema{"schema": Schema{"type": "object", "properties": properties}}},
+	}
+}
+
+// Unique id of a route, e.g. get_common_get-order-status_order_id
+func operationId(route Route) string {
+	path := strings.NewReplacer("/:", "_", "/", "_").Replace(route.Path)
+	return strings.ToLower(route.Method) + path
+}
+
+// Schema of a type. Named structs are added to schemas and referenced.
+func schemaOf(t reflect.Type, schemas map[string]interface{}) Schema {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return schemaOf(t.Elem(), schemas)
+	case reflect.String:
+		return Schema{"type": "string"}
+	case reflect.Bool:
+		return Schema{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return Schema{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return Schema{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return Schema{"type": "array", "items": schemaOf(t.Elem(), schemas)}
+	case reflect.Map:
+		return Schema{"type": "object", "additionalProperties": schemaOf(t.Elem(), schemas)}
+	case reflect.Struct:
+		if t == reflect.TypeOf(time.Time{}) {
+			return Schema{"type": "string", "format": "date-time"}
+		}
+		if _, ok := schemas[t.Name()]; !ok {
+			//Reserved first, for the types referencing themselves
+			schemas[t.Name()] = Schema{}
+			schemas[t.Name()] = structSchema(t, schemas)
+		}
+		return Schema{"$ref": "#/components/schemas/" + t.Name()}
+	}
+	return Schema{}
+}
+
+// Object schema of the exported fields of a struct
+func structSchema(t reflect.Type, schemas map[string]interface{}) Schema {
+	properties := Schema{}
+	var required []string
+	for index := 0; index < t.NumField(); index++ {
+		field := t.Field(index)
+		tag := strings.Split(field.Tag.Get("json"), ",")
+		if !field.IsExported() || tag[0] == "-" {
+			continue
+		}
+		name := tag[0]
+		if name == "" {
+			name = field.Name
+		}
+		schema := schemaOf(field.Type, schemas)
+		if rules(schema, field.Tag.Get("validate")) {
+			required = append(required, name)
+		}
+		properties[name] = schema
+	}
+	schema := Schema{"type": "object", "properties": properties}
+	if required != nil {
+		sort.Strings(required)
+		schema["required"] = required
+	}
+	return schema
+}
+
+// Query parameters of the fields of a struct with a 'query' tag, the embedded
+// structs included
+func queryParams(t reflect.Type, schemas map[string]interface{}) (params []Schema) {
+	for index := 0; index < t.NumField(); index++ {
+		field := t.Field(index)
+		if field.Anonymous {
+			params = append(params, queryParams(field.Type, schemas)...)
+			continue
+		}
+		name := field.Tag.Get("query")
+		if name == "" {
+			continue
+		}
+		schema := schemaOf(field.Type, schemas)
+		params = append(params, Schema{
+			"name": name, "in": "query", "required": rules(schema, field.Tag.Get("validate")),
+			"schema": schema,
+		})
+	}
+	return params
+}
+
+// Add the 'validate' rules of a field to its schema, and tell if it is required
+func rules(schema Schema, validate string) (required bool) {
+	for _, rule := range strings.Split(validate, ",") {
+		name, param, _ := strings.Cut(rule, "=")
+		if strings.HasPrefix(rule, "e164") {
+			name = "e164"
+		}
+		switch name {
+		case "required":
+			required = true
+		case "email":
+			schema["format"] = "email"
+		case "numeric":
+			schema["pattern"] = `^[-+]?[0-9]+(?:\.[0-9]+)?$`
+		case "price":
+			schema["pattern"] = `^[1-9][0-9]*$`
+		case "min", "max":
+			//Length of a string, value of a number
+			if bound, err := strconv.Atoi(param); err == nil {
+				key := map[string]string{"min": "minimum", "max": "maximum"}[name]
+				if schema["type"] == "string" {
+					key = map[string]string{"min": "minLength", "max": "maxLength"}[name]
+				}
+				schema[key] = bound
+			}
+		case "oneof":
+			schema["enum"] = strings.Fields(param)
+		case "e164":
+			schema["description"] = "10 digits, or E.164 (+919876543210)"
+		}
+	}
+	return required
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	//user defined package(s)
+	"online/models"
+
+	//Inbuild package(s)
+	"context"
+
+	//Third party package(s)
+	"gorm.io/gorm"
+)
+
+// Repository backed by a GORM connection
+type GormRepository struct {
+	Db *gorm.DB
+}
+
+var _ Repository = GormRepository{}
+
+func NewGormRepository(Db *gorm.DB) GormRepository {
+	return GormRepository{Db: Db}
+}
+
+func (r GormRepository) ReadRoleIdByRole(ctx context.Context, data models.User) (models.Roles, error) {
+	return ReadRoleIdByRole(r.Db.WithContext(ctx), data)
+}
+
+func (r GormRepository) CreateUser(ctx context.Context, data models.User) error {
+	return CreateUser(r.Db.WithContext(ctx), data)
+}
+
+func (r GormRepository) ReadUserByEmail(ctx context.Context, data models.User) (models.User, error) {
+	return ReadUserByEmail(r.Db.WithContext(ctx), data)
+}
+
+func (r GormRepository) ReadTokenByUserId(ctx context.Context, user models.User) (models.Authentication, error) {
+	return ReadTokenByUserId(r.Db.WithContext(ctx), user)
+}
+
+func (r GormRepository) AddToken(ctx context.Context, auth models.Authentication) error {
+	return AddToken(r.Db.WithContext(ctx), auth)
+}
+
+func (r GormRepository) DeleteToken(ctx context.Context, userId string) error {
+	return DeleteToken(r.Db.WithContext(ctx), userId)
+}
+
+func (r GormRepository) CreateProduct(ctx context.Context, Product models.ProductInfo) error {
+	return CreateProduct(r.Db.WithContext(ctx), Product)
+}
+
+func (r GormRepository) ReadProductByProductId(ctx context.Context, productId string) (models.ProductInfo, error) {
+	return ReadProductByProductId(r.Db.WithContext(ctx), productId)
+}
+
+func (r GormRepository) UpdateProductByProductId(ctx context.Context, ProductId string, Product models.ProductInfo) error {
+	return UpdateProductByProductId(r.Db.WithContext(ctx), ProductId, Product)
+}
+
+func (r GormRepository) DeleteProductByProductId(ctx context.Context, ProductId string) error {
+	return DeleteProductByProductId(r.Db.WithContext(ctx), ProductId)
+}
+
+func (r GormRepository) ReadAllProducts(ctx context.Context, query ListQuery) ([]models.ProductInfo, Page, error) {
+	return ReadAllProducts(r.Db.WithContext(ctx), query)
+}
+
+func (r GormRepository) ReadProductIdByProductData(ctx context.Context, Product models.OrderProductInfo) (models.ProductInfo, error) {
+	return ReadProductIdByProductData(r.Db.WithContext(ctx), Product)
+}
+
+func (r GormRepository) SearchProducts(ctx context.Context, search ProductSearch) (ProductSearchResult, error) {
+	return SearchProducts(r.Db.WithContext(ctx), search)
+}
+
+func (r GormRepository) CreateOrder(ctx context.Context, Order models.OrderProductInfo, event models.OrderEvent) (uint, error) {
+	return CreateOrder(r.Db.WithContext(ctx), Order, event)
+}
+
+func (r GormRepository) ReadOrdersByUser(ctx context.Context, userId string, query ListQuery) ([]models.OrderProductInfo, Page, error) {
+	return ReadOrdersByUser(r.Db.WithContext(ctx), userId, query)
+}
+
+func (r GormRepository) ReadOrdersByAdmin(ctx context.Context, query ListQuery) ([]models.OrderProductInfo, Page, error) {
+	return ReadOrdersByAdmin(r.Db.WithContext(ctx), query)
+}
+
+func (r GormRepository) ReadOrderByOrderId(ctx context.Context, orderId string) (models.OrderProductInfo, error) {
+	return ReadOrderByOrderId(r.Db.WithContext(ctx), orderId)
+}
+
+func (r GormRepository) UpdateOrderState(ctx context.Context, Order models.OrderProductInfo, event models.OrderEvent) error {
+	return UpdateOrderState(r.Db.WithContext(ctx), Order, event)
+}
+
+func (r GormRepository) ReadOrderEvents(ctx context.Context, orderId uint) ([]models.OrderEvent, error) {
+	return ReadOrderEvents(r.Db.WithContext(ctx), orderId)
+}
+
+func (r GormRepository) ReadOrderStatus(ctx context.Context, query ListQuery) ([]models.OrderProductInfo, Page, error) {
+	return ReadOrderStatus(r.Db.WithContext(ctx), query)
+}
@@ -0,0 +1,267 @@
+package repository
+
+import (
+	//user defined package(s)
+	"online/models"
+
+	//Inbuild package(s)
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	//Third party package(s)
+	"gorm.io/gorm"
+)
+
+// Query of a list: at most Limit rows matching Filters, ordered by Sort then
+// by id, after the row the cursor points to (keyset pagination, so a page
+// costs the same whatever its position)
+type ListQuery struct {
+	Limit int
+	//Field of the rows, prefixed by '-' for a descending order ("" for the id)
+	Sort string
+	//Field of the rows -> value they must equal
+	Filters map[string]string
+	//Last row of the previous page, nil for the first page
+	After *Cursor
+}
+
+// Position in a list: the sort and the sort value and id of a row
+type Cursor struct {
+	Sort  string `json:"s"`
+	Value string `json:"v"`
+	Id    uint   `json:"id"`
+}
+
+// A page of a list
+type Page struct {
+	//Rows matching the filters, on every page
+	Total int64
+	//Position of the next page, nil on the last one
+	Next *Cursor
+}
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Opaque form of the cursor, given to the clients
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// Decode a cursor given by a client, which must come from a list with the same sort
+func DecodeCursor(encoded, sort string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.Sort != sort {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
+
+// Field of the rows of a list, which it may be sorted and filtered by
+type field[T any] struct {
+	//SQL expression of the field
+	column string
+	//Compared as numbers (the prices are stored as text)
+	numeric bool
+	value   func(T) string
+}
+
+// Fields of the lists, by their name in the API. "id" is always present.
+var (
+	productFields = map[string]field[models.ProductInfo]{
+		"id":            {column: "product_id", numeric: true, value: func(p models.ProductInfo) string { return strconv.Itoa(int(p.ProductId)) }},
+		"brand_name":    {column: "brand_name", value: func(p models.ProductInfo) string { return p.BrandName }},
+		"ram_capacity":  {column: "ram_capacity", value: func(p models.ProductInfo) string { return p.RamCapacity }},
+		"product_price": {column: "CAST(product_price AS numeric)", numeric: true, value: func(p models.ProductInfo) string { return p.ProductPrice }},
+	}
+	orderFields = map[string]field[models.OrderProductInfo]{
+		"id":             {column: "order_id", numeric: true, value: func(o models.OrderProductInfo) string { return strconv.Itoa(int(o.OrderId)) }},
+		"user_id":        {column: "user_id", numeric: true, value: func(o models.OrderProductInfo) string { return strconv.Itoa(int(o.UserId)) }},
+		"brand_name":     {column: "brand_name", value: func(o models.OrderProductInfo) string { return o.BrandName }},
+		"payment_status": {column: "payment_status", value: func(o models.OrderProductInfo) string { return o.PaymentStatus }},
+		"total_price":    {column: "CAST(total_price AS numeric)", numeric: true, value: func(o models.OrderProductInfo) string { return o.TotalPrice }},
+	}
+	orderStatusFields = map[string]field[models.OrderProductInfo]{
+		"id":             orderFields["id"],
+		"order_status":   {column: "status", value: func(o models.OrderProductInfo) string { return string(o.Status) }},
+		"payment_status": orderFields["payment_status"],
+	}
+)
+
+// Sort field of the query and its direction
+func sortField[T any](query ListQuery, fields map[string]field[T]) (name string, f field[T], desc bool, err error) {
+	name = strings.TrimPrefix(query.Sort, "-")
+	desc = strings.HasPrefix(query.Sort, "-")
+	if name == "" {
+		name = "id"
+	}
+	f, ok := fields[name]
+	if !ok {
+		return name, f, desc, fmt.Errorf("unknown sort field %q", name)
+	}
+	return name, f, desc, nil
+}
+
+// Read a page of the rows of Db with a keyset query. The count of every
+// matching row is a second query, without the cursor.
+func listPage[T any](Db *gorm.DB, query ListQuery, fields map[string]field[T]) ([]T, Page, error) {
+	var page Page
+	_, sortBy, desc, err := sortField(query, fields)
+	if err != nil {
+		return nil, page, err
+	}
+	id := fields["id"]
+
+	//Filters, in a stable order so the statements can be cached
+	names := make([]string, 0, len(query.Filters))
+	for name := range query.Filters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		f, ok := fields[name]
+		if !ok {
+			return nil, page, fmt.Errorf("unknown filter field %q", name)
+		}
+		Db = Db.Where(f.column+" = ?", query.Filters[name])
+	}
+	var model T
+	if err := Db.Session(&gorm.Session{}).Model(&model).Count(&page.Total).Error; err != nil {
+		return nil, page, err
+	}
+
+	order, compare := "ASC", ">"
+	if desc {
+		order, compare = "DESC", "<"
+	}
+	if query.After != nil {
+		value, err := sortValue(sortBy, query.After.Value)
+		if err != nil {
+			return nil, page, ErrInvalidCursor
+		}
+		if sortBy.column == id.column {
+			Db = Db.Where(id.column+" "+compare+" ?", query.After.Id)
+		} else {
+			Db = Db.Where(fmt.Sprintf("(%[1]s %[2]s ? OR (%[1]s = ? AND %[3]s %[2]s ?))", sortBy.column, compare, id.column),
+				value, value, query.After.Id)
+		}
+	}
+	if sortBy.column != id.column {
+		Db = Db.Order(sortBy.column + " " + order)
+	}
+	//One more row than asked tells if there is a next page
+	var rows []T
+	if err := Db.Order(id.column + " " + order).Limit(query.Limit + 1).Find(&rows).Error; err != nil {
+		return nil, page, err
+	}
+	return pageOf(rows, query, sortBy, id, &page), page, nil
+}
+
+// Value of a cursor as its column expects it
+func sortValue[T any](f field[T], value string) (interface{}, error) {
+	if f.numeric {
+		return strconv.ParseFloat(value, 64)
+	}
+	return value, nil
+}
+
+// Keep at most Limit rows, and point the next page after the last one
+func pageOf[T any](rows []T, query ListQuery, sortBy, id field[T], page *Page) []T {
+	if len(rows) <= query.Limit {
+		return rows
+	}
+	rows = rows[:query.Limit]
+	last := rows[len(rows)-1]
+	lastId, _ := strconv.Atoi(id.value(last))
+	page.Next = &Cursor{Sort: query.Sort, Value: sortBy.value(last), Id: uint(lastId)}
+	return rows
+}
+
+// Same page as listPage, from rows already in memory
+func listRows[T any](rows []T, query ListQuery, fields map[string]field[T]) ([]T, Page, error) {
+	var page Page
+	_, sortBy, desc, err := sortField(query, fields)
+	if err != nil {
+		return nil, page, err
+	}
+	id := fields["id"]
+	for name := range query.Filters {
+		if _, ok := fields[name]; !ok {
+			return nil, page, fmt.Errorf("unknown filter field %q", name)
+		}
+	}
+
+	var matching []T
+	for _, row := range rows {
+		keep := true
+		for name, value := range query.Filters {
+			keep = keep && fields[name].value(row) == value
+		}
+		if keep {
+			matching = append(matching, row)
+		}
+	}
+	page.Total = int64(len(matching))
+
+	//Order of two rows: the sort field, then the id
+	compare := func(a, b T) int {
+		if result := compareValues(sortBy, sortBy.value(a), sortBy.value(b)); result != 0 {
+			return result
+		}
+		return compareValues(id, id.value(a), id.value(b))
+	}
+	if desc {
+		ascending := compare
+		compare = func(a, b T) int { return -ascending(a, b) }
+	}
+	sort.SliceStable(matching, func(i, j int) bool { return compare(matching[i], matching[j]) < 0 })
+
+	if query.After != nil {
+		if _, err := sortValue(sortBy, query.After.Value); err != nil {
+			return nil, page, ErrInvalidCursor
+		}
+		after := matching[:0:0]
+		for _, row := range matching {
+			result := compareValues(sortBy, sortBy.value(row), query.After.Value)
+			if result == 0 {
+				result = compareValues(id, id.value(row), strconv.Itoa(int(query.After.Id)))
+			}
+			if desc {
+				result = -result
+			}
+			if result > 0 {
+				after = append(after, row)
+			}
+		}
+		matching = after
+	}
+	if len(matching) > query.Limit+1 {
+		matching = matching[:query.Limit+1]
+	}
+	return pageOf(matching, query, sortBy, id, &page), page, nil
+}
+
+// Compare two values of a field like the database does
+func compareValues[T any](f field[T], a, b string) int {
+	if f.numeric {
+		x, _ := strconv.ParseFloat(a, 64)
+		y, _ := strconv.ParseFloat(b, 64)
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(a, b)
+}
@@ -0,0 +1,335 @@
+package repository
+
+import (
+	//user defined package(s)
+	"online/models"
+
+	//Inbuild package(s)
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	//Third party package(s)
+	"gorm.io/gorm"
+)
+
+// In-memory repository, safe for concurrent use. It mirrors the behaviour of
+// the GORM queries (list pages, conditional state updates with their events,
+// not-found errors) so the handlers can be exercised without a database.
+type MemoryRepository struct {
+	mu         sync.RWMutex
+	roles      []models.Roles
+	users      []models.User
+	tokens     []models.Authentication
+	products   []models.ProductInfo
+	orders     []models.OrderProductInfo
+	events     []models.OrderEvent
+	userSeq    uint
+	productSeq uint
+	orderSeq   uint
+	eventSeq   uint
+}
+
+var _ Repository = (*MemoryRepository)(nil)
+
+// Create an empty in-memory repository with the default roles
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		roles: []models.Roles{
+			{RoleId: 1, Role: "admin"},
+			{RoleId: 2, Role: "user"},
+		},
+	}
+}
+
+// Parse a path id, any invalid id simply matches no rows
+func parseId(id string) uint {
+	value, err := strconv.Atoi(id)
+	if err != nil || value < 0 {
+		return 0
+	}
+	return uint(value)
+}
+
+func (r *MemoryRepository) ReadRoleIdByRole(_ context.Context, data models.User) (models.Roles, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, role := range r.roles {
+		if role.Role == data.Role {
+			return models.Roles{RoleId: role.RoleId}, nil
+		}
+	}
+	return models.Roles{}, gorm.ErrRecordNotFound
+}
+
+func (r *MemoryRepository) CreateUser(_ context.Context, data models.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, user := range r.users {
+		if user.Email == data.Email {
+			return gorm.ErrDuplicatedKey
+		}
+	}
+	r.userSeq++
+	data.UserId = r.userSeq
+	r.users = append(r.users, data)
+	return nil
+}
+
+func (r *MemoryRepository) ReadUserByEmail(_ context.Context, data models.User) (models.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, user := range r.users {
+		if user.Email == data.Email {
+			return user, nil
+		}
+	}
+	return data, gorm.ErrRecordNotFound
+}
+
+func (r *MemoryRepository) ReadTokenByUserId(_ context.Context, user models.User) (models.Authentication, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, auth := range r.tokens {
+		if auth.UserId == user.UserId {
+			return auth, nil
+		}
+	}
+	return models.Authentication{}, gorm.ErrRecordNotFound
+}
+
+func (r *MemoryRepository) AddToken(_ context.Context, auth models.Authentication) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, token := range r.tokens {
+		if token.UserId == auth.UserId {
+			return gorm.ErrDuplicatedKey
+		}
+	}
+	r.tokens = append(r.tokens, auth)
+	return nil
+}
+
+func (r *MemoryRepository) DeleteToken(_ context.Context, userId string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	id := parseId(userId)
+	tokens := r.tokens[:0]
+	for _, token := range r.tokens {
+		if token.UserId != id {
+			tokens = append(tokens, token)
+		}
+	}
+	r.tokens = tokens
+	return nil
+}
+
+func (r *MemoryRepository) CreateProduct(_ context.Context, Product models.ProductInfo) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.productSeq++
+	Product.ProductId = r.productSeq
+	r.products = append(r.products, Product)
+	return nil
+}
+
+func (r *MemoryRepository) ReadProductByProductId(_ context.Context, productId string) (models.ProductInfo, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	id := parseId(productId)
+	for _, product := range r.products {
+		if product.ProductId == id {
+			return product, nil
+		}
+	}
+	return models.ProductInfo{}, gorm.ErrRecordNotFound
+}
+
+func (r *MemoryRepository) UpdateProductByProductId(_ context.Context, ProductId string, Product models.ProductInfo) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	id := parseId(ProductId)
+	for index, product := range r.products {
+		if product.ProductId == id {
+			Product.ProductId = id
+			r.products[index] = Product
+			return nil
+		}
+	}
+	return nil
+}
+
+func (r *MemoryRepository) DeleteProductByProductId(_ context.Context, ProductId string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	id := parseId(ProductId)
+	products := r.products[:0]
+	for _, product := range r.products {
+		if product.ProductId != id {
+			products = append(products, product)
+		}
+	}
+	r.products = products
+	return nil
+}
+
+func (r *MemoryRepository) ReadAllProducts(_ context.Context, query ListQuery) ([]models.ProductInfo, Page, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return listRows(r.products, query, productFields)
+}
+
+func (r *MemoryRepository) ReadProductIdByProductData(_ context.Context, Product models.OrderProductInfo) (models.ProductInfo, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, product := range r.products {
+		if product.BrandName == Product.BrandName && product.ProductPrice == Product.ProductPrice &&
+			product.RamCapacity == Product.RamCapacity && product.RamPrice == Product.RamPrice {
+			return models.ProductInfo{ProductId: product.ProductId}, nil
+		}
+	}
+	return models.ProductInfo{}, gorm.ErrRecordNotFound
+}
+
+// Search like the databases without full-text search do
+func (r *MemoryRepository) SearchProducts(_ context.Context, search ProductSearch) (result ProductSearchResult, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	terms := searchTerms(search.Text)
+	matches := func(product models.ProductInfo, skip string) bool {
+		for _, term := range terms {
+			if !strings.Contains(strings.ToLower(product.BrandName), term) && !strings.Contains(strings.ToLower(product.RamCapacity), term) {
+				return false
+			}
+		}
+		price, _ := strconv.ParseFloat(product.ProductPrice, 64)
+		return (search.BrandName == "" || skip == "brand_name" || product.BrandName == search.BrandName) &&
+			(search.RamCapacity == "" || skip == "ram_capacity" || product.RamCapacity == search.RamCapacity) &&
+			(search.MinPrice <= 0 || price >= search.MinPrice) &&
+			(search.MaxPrice <= 0 || price <= search.MaxPrice)
+	}
+	brands, rams := map[string]int64{}, map[string]int64{}
+	for _, product := range r.products {
+		if matches(product, "") {
+			result.Total++
+			if len(result.Products) < search.Limit {
+				result.Products = append(result.Products, product)
+			}
+		}
+		if matches(product, "brand_name") {
+			brands[product.BrandName]++
+		}
+		if matches(product, "ram_capacity") {
+			rams[product.RamCapacity]++
+		}
+	}
+	result.BrandNames, result.RamCapacities = facets(brands), facets(rams)
+	return
+}
+
+// Facets of the counts, the most frequent value first
+func facets(counts map[string]int64) []Facet {
+	var facets []Facet
+	for value, count := range counts {
+		facets = append(facets, Facet{Value: value, Count: count})
+	}
+	sort.Slice(facets, func(i, j int) bool {
+		if facets[i].Count != facets[j].Count {
+			return facets[i].Count > facets[j].Count
+		}
+		return facets[i].Value < facets[j].Value
+	})
+	return facets
+}
+
+func (r *MemoryRepository) CreateOrder(_ context.Context, Order models.OrderProductInfo, event models.OrderEvent) (uint, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.orderSeq++
+	Order.OrderId = r.orderSeq
+	if Order.Status == "" {
+		Order.Status = models.OrderPendingPayment
+	}
+	if Order.PaymentStatus == "" {
+		Order.PaymentStatus = "pending"
+	}
+	Order.CreatedAt, Order.UpdatedAt = time.Now(), time.Now()
+	r.orders = append(r.orders, Order)
+	event.OrderId, event.To = Order.OrderId, Order.Status
+	r.addOrderEvent(event)
+	return Order.OrderId, nil
+}
+
+func (r *MemoryRepository) ReadOrdersByUser(_ context.Context, userId string, query ListQuery) ([]models.OrderProductInfo, Page, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	id := parseId(userId)
+	var Orders []models.OrderProductInfo
+	for _, order := range r.orders {
+		if order.UserId == id {
+			Orders = append(Orders, order)
+		}
+	}
+	return listRows(Orders, query, orderFields)
+}
+
+func (r *MemoryRepository) ReadOrdersByAdmin(_ context.Context, query ListQuery) ([]models.OrderProductInfo, Page, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return listRows(r.orders, query, orderFields)
+}
+
+func (r *MemoryRepository) ReadOrderByOrderId(_ context.Context, orderId string) (models.OrderProductInfo, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	id := parseId(orderId)
+	for _, order := range r.orders {
+		if order.OrderId == id {
+			return order, nil
+		}
+	}
+	return models.OrderProductInfo{}, gorm.ErrRecordNotFound
+}
+
+func (r *MemoryRepository) UpdateOrderState(_ context.Context, Order models.OrderProductInfo, event models.OrderEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for index, order := range r.orders {
+		if order.OrderId == Order.OrderId && order.Status == event.From {
+			r.orders[index].Status, r.orders[index].PaymentStatus = Order.Status, Order.PaymentStatus
+			r.orders[index].UpdatedAt = time.Now()
+			event.OrderId, event.To = Order.OrderId, Order.Status
+			r.addOrderEvent(event)
+			return nil
+		}
+	}
+	return ErrOrderStateChanged
+}
+
+// Append an event, the lock held
+func (r *MemoryRepository) addOrderEvent(event models.OrderEvent) {
+	r.eventSeq++
+	event.EventId, event.CreatedAt = r.eventSeq, time.Now()
+	r.events = append(r.events, event)
+}
+
+func (r *MemoryRepository) ReadOrderEvents(_ context.Context, orderId uint) ([]models.OrderEvent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var events []models.OrderEvent
+	for _, event := range r.events {
+		if event.OrderId == orderId {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func (r *MemoryRepository) ReadOrderStatus(_ context.Context, query ListQuery) ([]models.OrderProductInfo, Page, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return listRows(r.orders, query, orderStatusFields)
+}
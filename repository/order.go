@@ -1,88 +1,75 @@
-package repository
-
-import (
-	//user defined package(s)
-	"online/models"
-
-	//Third party package(s)
-	"gorm.io/gorm"
-)
-
-// Adding a Order into OrderProductInfo table
-func CreateOrder(Db *gorm.DB, Order models.OrderProductInfo) error {
-	err := Db.Create(&Order).Error
-	return err
-}
-
-// Delete a Order by Order-id
-func DeleteOrderByOrderId(Db *gorm.DB, orderId string) (err error) {
-	var Order models.OrderProductInfo
-	err = Db.Where("order_id=?", orderId).Delete(&Order).Error
-	return
-}
-
-// Retrieve orders by user from OrderProductInfo table
-func ReadOrdersByUser(Db *gorm.DB, userId string) (Orders []models.OrderProductInfo, err error) {
-	err = Db.Where("user_id=?", userId).Find(&Orders).Error
-	return
-}
-
-// Retrieve orders by admin from OrderProductInfo table
-func ReadOrdersByAdmin(Db *gorm.DB) (Orders []models.OrderProductInfo, err error) {
-	err = Db.Find(&Orders).Error
-	return
-}
-
-// Retrieve a Order by Order-id
-func ReadOrderByOrderIdUs(Db *gorm.DB, orderId string) (Order models.OrderProductInfo, err error) {
-	err = Db.Unscoped().Where("order_id=?", orderId).First(&Order).Error
-	return
-}
-
-// Retrieve a Order by Order-id
-func ReadOrderByOrderId(Db *gorm.DB, orderId string) (Order models.OrderProductInfo, err error) {
-	err = Db.Where("order_id=?", orderId).First(&Order).Error
-	return
-}
-
-// Update a Product by Product-id
-func UpdateOrderById(Db *gorm.DB, Order models.OrderProductInfo) (err error) {
-	err = Db.Where("order_id=?", Order.OrderId).Save(&Order).Error
-	return
-}
-
-// Adding a status of the order into Orderstatus table
-func CreateOrderStatus(Db *gorm.DB, Order models.OrderStatus) error {
-	err := Db.Create(&Order).Error
-	return err
-}
-
-// Retrieve a max Order-id
-func ReadOrderId(Db *gorm.DB) (orderId uint) {
-	Db.Model(&models.OrderProductInfo{}).Select("max(order_id)").Scan(&orderId)
-	return
-}
-
-// Update a status of the order in the Orderstatus table
-func UpdateOrderStatus(Db *gorm.DB, Order models.OrderStatus) error {
-	err := Db.Where("order_id=?", Order.OrderId).Save(&Order).Error
-	return err
-}
-
-// Retrieve a OrderStatus by Order-id
-func ReadOrderStatusByOrderId(Db *gorm.DB, orderId uint) (Order models.OrderStatus, err error) {
-	err = Db.Unscoped().Where("order_id=?", orderId).First(&Order).Error
-	return
-}
-
-// Retrieve all Order Statuses
-func ReadOrderStatus(Db *gorm.DB) (OrderStatus []models.OrderStatus, err error) {
-	err = Db.Unscoped().Find(&OrderStatus).Error
-	return
-}
-
-// Delete a status of the order in the Orderstatus table
-func DeleteOrderStatus(Db *gorm.DB, Order models.OrderStatus) error {
-	err := Db.Where("order_id=?", Order.OrderId).Delete(&Order).Error
-	return err
-}
+package repository
+
+import (
+	//user defined package(s)
+	"online/models"
+
+	//Inbuild package(s)
+	"errors"
+
+	//Third party package(s)
+	"gorm.io/gorm"
+)
+
+// The order left the state it was read in before its new state was saved
+var ErrOrderStateChanged = errors.New("order state changed meanwhile")
+
+// Adding a Order into OrderProductInfo table with the first event of its
+// timeline, in one transaction, and return the id of the order
+func CreateOrder(Db *gorm.DB, Order models.OrderProductInfo, event models.OrderEvent) (orderId uint, err error) {
+	if Order.Status == "" {
+		Order.Status = models.OrderPendingPayment
+	}
+	err = Db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&Order).Error; err != nil {
+			return err
+		}
+		event.OrderId, event.To = Order.OrderId, Order.Status
+		return tx.Create(&event).Error
+	})
+	return Order.OrderId, err
+}
+
+// Retrieve a page of the orders of a user from OrderProductInfo table
+func ReadOrdersByUser(Db *gorm.DB, userId string, query ListQuery) ([]models.OrderProductInfo, Page, error) {
+	return listPage(Db.Where("user_id=?", userId), query, orderFields)
+}
+
+// Retrieve a page of every order from OrderProductInfo table
+func ReadOrdersByAdmin(Db *gorm.DB, query ListQuery) ([]models.OrderProductInfo, Page, error) {
+	return listPage(Db, query, orderFields)
+}
+
+// Retrieve a Order by Order-id
+func ReadOrderByOrderId(Db *gorm.DB, orderId string) (Order models.OrderProductInfo, err error) {
+	err = Db.Where("order_id=?", orderId).First(&Order).Error
+	return
+}
+
+// Save the state the order moved to with Transition, unless it left the
+// state event.From meanwhile, and record the event in the same transaction
+func UpdateOrderState(Db *gorm.DB, Order models.OrderProductInfo, event models.OrderEvent) error {
+	return Db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.OrderProductInfo{}).Where("order_id = ? AND status = ?", Order.OrderId, event.From).
+			Updates(map[string]interface{}{"status": Order.Status, "payment_status": Order.PaymentStatus})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrOrderStateChanged
+		}
+		event.OrderId, event.To = Order.OrderId, Order.Status
+		return tx.Create(&event).Error
+	})
+}
+
+// Retrieve the timeline of an order, oldest event first
+func ReadOrderEvents(Db *gorm.DB, orderId uint) (events []models.OrderEvent, err error) {
+	err = Db.Where("order_id = ?", orderId).Order("event_id").Find(&events).Error
+	return
+}
+
+// Retrieve a page of the orders with their state
+func ReadOrderStatus(Db *gorm.DB, query ListQuery) ([]models.OrderProductInfo, Page, error) {
+	return listPage(Db, query, orderStatusFields)
+}
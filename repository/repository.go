@@ -0,0 +1,55 @@
+package repository
+
+import (
+	//user defined package(s)
+	"online/models"
+
+	//Inbuild package(s)
+	"context"
+)
+
+// Access to the users and roles tables
+type UserRepository interface {
+	ReadRoleIdByRole(ctx context.Context, data models.User) (models.Roles, error)
+	CreateUser(ctx context.Context, data models.User) error
+	ReadUserByEmail(ctx context.Context, data models.User) (models.User, error)
+}
+
+// Access to the authentications table
+type TokenRepository interface {
+	ReadTokenByUserId(ctx context.Context, user models.User) (models.Authentication, error)
+	AddToken(ctx context.Context, auth models.Authentication) error
+	DeleteToken(ctx context.Context, userId string) error
+}
+
+// Access to the products table
+type ProductRepository interface {
+	CreateProduct(ctx context.Context, Product models.ProductInfo) error
+	ReadProductByProductId(ctx context.Context, productId string) (models.ProductInfo, error)
+	UpdateProductByProductId(ctx context.Context, ProductId string, Product models.ProductInfo) error
+	DeleteProductByProductId(ctx context.Context, ProductId string) error
+	ReadAllProducts(ctx context.Context, query ListQuery) ([]models.ProductInfo, Page, error)
+	ReadProductIdByProductData(ctx context.Context, Product models.OrderProductInfo) (models.ProductInfo, error)
+	SearchProducts(ctx context.Context, search ProductSearch) (ProductSearchResult, error)
+}
+
+// Access to the orders table, which also holds their state, and to the
+// timeline of their states. An order is never saved whole once created: its
+// state only changes through UpdateOrderState, along with its event.
+type OrderRepository interface {
+	CreateOrder(ctx context.Context, Order models.OrderProductInfo, event models.OrderEvent) (uint, error)
+	ReadOrdersByUser(ctx context.Context, userId string, query ListQuery) ([]models.OrderProductInfo, Page, error)
+	ReadOrdersByAdmin(ctx context.Context, query ListQuery) ([]models.OrderProductInfo, Page, error)
+	ReadOrderByOrderId(ctx context.Context, orderId string) (models.OrderProductInfo, error)
+	UpdateOrderState(ctx context.Context, Order models.OrderProductInfo, event models.OrderEvent) error
+	ReadOrderEvents(ctx context.Context, orderId uint) ([]models.OrderEvent, error)
+	ReadOrderStatus(ctx context.Context, query ListQuery) ([]models.OrderProductInfo, Page, error)
+}
+
+// All the repositories needed by the handlers
+type Repository interface {
+	UserRepository
+	TokenRepository
+	ProductRepository
+	OrderRepository
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	//user defined package(s)
+	"online/models"
+
+	//Inbuild package(s)
+	"strings"
+	"unicode"
+
+	//Third party package(s)
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Search of the catalog. Each word of Text must start a word of the brand or
+// RAM capacity of a product (Postgres full-text search), or be part of them on
+// the other databases. The empty fields don't filter.
+type ProductSearch struct {
+	Text        string
+	BrandName   string
+	RamCapacity string
+	MinPrice    float64
+	MaxPrice    float64
+	Limit       int
+}
+
+// Count of the matching products having a value of a field
+type Facet struct {
+	Value string
+	Count int64
+}
+
+// Best matching products, first Limit of Total, and the facets of the search.
+// The facet of a field counts the products matching every filter but its own,
+// so a client can offer the other brands next to the chosen one.
+type ProductSearchResult struct {
+	Products      []models.ProductInfo
+	Total         int64
+	BrandNames    []Facet
+	RamCapacities []Facet
+}
+
+// Lower-case words of a search text, letters and digits only
+func searchTerms(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// Document the full-text search matches, also indexed by the 0004 migration
+const searchDocument = "to_tsvector('simple', coalesce(brand_name, '') || ' ' || coalesce(ram_capacity, ''))"
+
+// Search the products table
+func SearchProducts(Db *gorm.DB, search ProductSearch) (result ProductSearchResult, err error) {
+	terms := searchTerms(search.Text)
+	fullText := Db.Dialector.Name() == "postgres"
+	//Every word as the prefix of a word
+	tsquery := strings.Join(terms, ":* & ") + ":*"
+
+	//Products matching the search, but the filter of the field named skip
+	matching := func(skip string) *gorm.DB {
+		query := Db.Session(&gorm.Session{}).Model(&models.ProductInfo{})
+		if len(terms) > 0 {
+			if fullText {
+				query = query.Where(searchDocument+" @@ to_tsquery('simple', ?)", tsquery)
+			} else {
+				for _, term := range terms {
+					query = query.Where("(LOWER(brand_name) LIKE ? OR LOWER(ram_capacity) LIKE ?)", "%"+term+"%", "%"+term+"%")
+				}
+			}
+		}
+		if search.BrandName != "" && skip != "brand_name" {
+			query = query.Where("brand_name = ?", search.BrandName)
+		}
+		if search.RamCapacity != "" && skip != "ram_capacity" {
+			query = query.Where("ram_capacity = ?", search.RamCapacity)
+		}
+		if search.MinPrice > 0 {
+			query = query.Where("CAST(product_price AS numeric) >= ?", search.MinPrice)
+		}
+		if search.MaxPrice > 0 {
+			query = query.Where("CAST(product_price AS numeric) <= ?", search.MaxPrice)
+		}
+		return query
+	}
+
+	if err = matching("").Count(&result.Total).Error; err != nil {
+		return
+	}
+	var order interface{} = "product_id"
+	if fullText && len(terms) > 0 {
+		//Best ranked first
+		order = clause.OrderBy{Expression: clause.Expr{
+			SQL:  "ts_rank(" + searchDocument + ", to_tsquery('simple', ?)) DESC, product_id",
+			Vars: []interface{}{tsquery},
+		}}
+	}
+	if err = matching("").Order(order).Limit(search.Limit).Find(&result.Products).Error; err != nil {
+		return
+	}
+	if err = matching("brand_name").Select("brand_name AS value, COUNT(*) AS count").
+		Group("brand_name").Order("count DESC, value").Scan(&result.BrandNames).Error; err != nil {
+		return
+	}
+	err = matching("ram_capacity").Select("ram_capacity AS value, COUNT(*) AS count").
+		Group("ram_capacity").Order("count DESC, value").Scan(&result.RamCapacities).Error
+	return
+}
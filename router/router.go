@@ -1,50 +1,105 @@
-package router
-
-import (
-	//user defined packages
-	"online/handler"
-	"online/middleware"
-
-	//Third party packages
-	"github.com/labstack/echo"
-	"gorm.io/gorm"
-)
-
-// Signup and Login Handlers
-func LoginHandlers(Db *gorm.DB, app *echo.Echo) {
-	handler := handler.Database{Connection: Db}
-	app.POST("/signup", handler.Signup)
-	app.POST("/login", handler.Login)
-}
-
-// These handlers are accessible only by admin
-func AdminHandlers(Db *gorm.DB, app *echo.Echo) {
-	handler := handler.Database{Connection: Db}
-	middleware := middleware.Database{Connection: Db}
-	admin := app.Group("/admin", middleware.AuthMiddleware)
-	admin.POST("/post-product", handler.PostProduct)
-	admin.PUT("/update-product/:product_id", handler.UpdateProductById)
-	admin.DELETE("/delete-product/:product_id", handler.DeleteProductById)
-	admin.PUT("/update-status/:order_id", handler.UpdateOrderStatusById)
-	admin.GET("/get-order-statuses", handler.GetAllOrderStatus)
-}
-
-// These handlers are accessible only by user
-func UserHandlers(Db *gorm.DB, app *echo.Echo) {
-	handler := handler.Database{Connection: Db}
-	middleware := middleware.Database{Connection: Db}
-	user := app.Group("/user", middleware.AuthMiddleware)
-	user.POST("/post-order", handler.AddOrder)
-	user.DELETE("/cancel-order/:order_id", handler.CancelOrderById)
-	user.POST("/payment/:order_id", handler.Payment)
-}
-
-// These handlers are accessible by both admin and user
-func CommonHandlers(Db *gorm.DB, app *echo.Echo) {
-	handler := handler.Database{Connection: Db}
-	middleware := middleware.Database{Connection: Db}
-	common := app.Group("/common", middleware.AuthMiddleware)
-	common.GET("/get-all-products", handler.GetAllProducts)
-	common.GET("/get-orders", handler.GetOrders)
-	common.GET("/get-order-status/:order_id", handler.GetOrderStatusById)
-}
+package router
+
+import (
+	//user defined packages
+	"online/handler"
+	"online/metrics"
+	"online/middleware"
+
+	//Inbuild packages
+	"time"
+
+	//Third party packages
+	"github.com/labstack/echo"
+)
+
+// Prefix of the current version of the API
+const APIPrefix = "/api/v1"
+
+// Old routes, kept as aliases of the /api/v1 routes replacing them until the
+// clients moved. Their responses carry a Deprecation header since this date.
+var (
+	DeprecatedSince = time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+	Successors      = map[string]string{
+		"POST /signup":                             "POST /api/v1/users",
+		"POST /login":                              "POST /api/v1/sessions",
+		"POST /admin/post-product":                 "POST /api/v1/products",
+		"PUT /admin/update-product/:product_id":    "PATCH /api/v1/products/:product_id",
+		"DELETE /admin/delete-product/:product_id": "DELETE /api/v1/products/:product_id",
+		"PUT /admin/update-status/:order_id":       "PUT /api/v1/orders/:order_id/status",
+		"GET /admin/get-order-statuses":            "GET /api/v1/order-statuses",
+		"POST /user/post-order":                    "POST /api/v1/orders",
+		"DELETE /user/cancel-order/:order_id":      "DELETE /api/v1/orders/:order_id",
+		"POST /user/payment/:order_id":             "POST /api/v1/orders/:order_id/payments",
+		"GET /common/get-all-products":             "GET /api/v1/products",
+		"GET /common/get-orders":                   "GET /api/v1/orders",
+		"GET /common/get-order-status/:order_id":   "GET /api/v1/orders/:order_id/status",
+	}
+	deprecated = middleware.Deprecated(DeprecatedSince, Successors)
+)
+
+// Liveness and readiness probes, accessible without a token
+func HealthHandlers(health handler.Health, app *echo.Echo) {
+	app.GET("/healthz", health.Live)
+	app.GET("/readyz", health.Ready)
+}
+
+// Prometheus metrics, accessible without a token
+func MetricsHandlers(app *echo.Echo) {
+	app.GET("/metrics", metrics.Handler())
+}
+
+// Resources of the API. Each handler checks the permission it needs.
+func APIHandlers(handler handler.Database, app *echo.Echo) {
+	api := app.Group(APIPrefix)
+	auth := handler.Auth.AuthMiddleware
+	api.POST("/users", handler.Signup)
+	api.POST("/sessions", handler.Login)
+
+	api.GET("/products", handler.GetAllProducts, auth)
+	api.GET("/products/search", handler.SearchProducts, auth)
+	api.POST("/products", handler.PostProduct, auth)
+	api.PATCH("/products/:product_id", handler.UpdateProductById, auth)
+	api.DELETE("/products/:product_id", handler.DeleteProductById, auth)
+
+	api.GET("/orders", handler.GetOrders, auth)
+	api.POST("/orders", handler.AddOrder, auth)
+	api.DELETE("/orders/:order_id", handler.CancelOrderById, auth)
+	api.GET("/orders/:order_id/timeline", handler.GetOrderTimeline, auth)
+	api.POST("/orders/:order_id/payments", handler.Payment, auth)
+	api.GET("/orders/:order_id/status", handler.GetOrderStatusById, auth)
+	api.PUT("/orders/:order_id/status", handler.UpdateOrderStatusById, auth)
+	api.GET("/order-statuses", handler.GetAllOrderStatus, auth)
+}
+
+// Signup and Login Handlers, deprecated for the /api/v1 ones
+func LoginHandlers(handler handler.Database, app *echo.Echo) {
+	app.POST("/signup", handler.Signup, deprecated)
+	app.POST("/login", handler.Login, deprecated)
+}
+
+// Old routes of the admins, deprecated for the /api/v1 ones
+func AdminHandlers(handler handler.Database, app *echo.Echo) {
+	admin := app.Group("/admin", deprecated, handler.Auth.AuthMiddleware)
+	admin.POST("/post-product", handler.PostProduct)
+	admin.PUT("/update-product/:product_id", handler.UpdateProductById)
+	admin.DELETE("/delete-product/:product_id", handler.DeleteProductById)
+	admin.PUT("/update-status/:order_id", handler.UpdateOrderStatusById)
+	admin.GET("/get-order-statuses", handler.GetAllOrderStatus)
+}
+
+// Old routes of the users, deprecated for the /api/v1 ones
+func UserHandlers(handler handler.Database, app *echo.Echo) {
+	user := app.Group("/user", deprecated, handler.Auth.AuthMiddleware)
+	user.POST("/post-order", handler.AddOrder)
+	user.DELETE("/cancel-order/:order_id", handler.CancelOrderById)
+	user.POST("/payment/:order_id", handler.Payment)
+}
+
+// Old routes of both admins and users, deprecated for the /api/v1 ones
+func CommonHandlers(handler handler.Database, app *echo.Echo) {
+	common := app.Group("/common", deprecated, handler.Auth.AuthMiddleware)
+	common.GET("/get-all-products", handler.GetAllProducts)
+	common.GET("/get-orders", handler.GetOrders)
+	common.GET("/get-order-status/:order_id", handler.GetOrderStatusById)
+}
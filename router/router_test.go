@@ -0,0 +1,133 @@
+package router
+
+import (
+	//user defined packages
+	"online/config"
+	"online/handler"
+	"online/repository"
+
+	//Inbuild packages
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	//Third party packages
+	"github.com/labstack/echo"
+)
+
+// Echo instance with every route of the server
+func newServer() *echo.Echo {
+	app := echo.New()
+	HealthHandlers(handler.Health{}, app)
+	MetricsHandlers(app)
+	DocsHandlers(app)
+	database := handler.New(repository.NewMemoryRepository(), config.JWT{Secret: "test-secret"})
+	APIHandlers(database, app)
+	LoginHandlers(database, app)
+	AdminHandlers(database, app)
+	UserHandlers(database, app)
+	CommonHandlers(database, app)
+	return app
+}
+
+func TestSpecCoversRoutes(t *testing.T) {
+	documented := map[string]bool{}
+	for _, route := range Routes {
+		documented[route.Key()] = true
+	}
+	registered := map[string]bool{}
+	for _, route := range newServer().Routes() {
+		//Catch-all routes added by the groups to run their middleware
+		if strings.Contains(route.Name, "(*Group).Use") {
+			continue
+		}
+		key := route.Method + " " + route.Path
+		registered[key] = true
+		if !documented[key] {
+			t.Errorf("%s is registered without an entry in router.Routes", key)
+		}
+	}
+	for key := range documented {
+		if !registered[key] {
+			t.Errorf("%s is documented but not registered", key)
+		}
+	}
+	for alias, successor := range Successors {
+		if !registered[alias] || !registered[successor] {
+			t.Errorf("%s is an alias of %s, both must be registered", alias, successor)
+		}
+	}
+}
+
+func TestDeprecatedRoutes(t *testing.T) {
+	app := newServer()
+	tests := []struct {
+		method, url, link string
+	}{
+		{http.MethodGet, "/common/get-order-status/7", "</api/v1/orders/7/status>; rel=\"successor-version\""},
+		{http.MethodPost, "/login", "</api/v1/sessions>; rel=\"successor-version\""},
+		{http.MethodGet, "/api/v1/orders/7/status", ""},
+	}
+	for _, test := range tests {
+		resp := httptest.NewRecorder()
+		app.ServeHTTP(resp, httptest.NewRequest(test.method, test.url, nil))
+		deprecation := resp.Header().Get("Deprecation")
+		if test.link == "" {
+			if deprecation != "" {
+				t.Errorf("%s %s: expected no Deprecation header, got %q", test.method, test.url, deprecation)
+			}
+			continue
+		}
+		//Set even when the request fails, here for the missing token or body
+		if want := fmt.Sprintf("@%d", DeprecatedSince.Unix()); deprecation != want {
+			t.Errorf("%s %s: expected Deprecation %q, got %q", test.method, test.url, want, deprecation)
+		}
+		if got := resp.Header().Get("Link"); got != test.link {
+			t.Errorf("%s %s: expected Link %q, got %q", test.method, test.url, test.link, got)
+		}
+	}
+}
+
+func TestOpenAPIDocument(t *testing.T) {
+	resp := httptest.NewRecorder()
+	newServer().ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	if resp.Code != http.StatusOK {
+		t.Fatalf("expected: %d, got: %d", http.StatusOK, resp.Code)
+	}
+	var doc struct {
+		OpenAPI    string                                       `json:"openapi"`
+		Paths      map[string]map[string]map[string]interface{} `json:"paths"`
+		Components struct {
+			Schemas map[string]map[string]interface{} `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(resp.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("decode the document: %v", err)
+	}
+	if doc.OpenAPI != "3.0.3" {
+		t.Errorf("expected an OpenAPI 3 document, got %q", doc.OpenAPI)
+	}
+	payment := doc.Paths["/user/payment/{order_id}"]["post"]
+	if payment == nil || payment["security"] == nil || payment["parameters"] == nil {
+		t.Errorf("expected the payment with its token and order_id, got %v", payment)
+	}
+
+	//The schemas come from the DTOs, with their validation rules
+	signup := doc.Components.Schemas["SignupReq"]
+	required, _ := json.Marshal(signup["required"])
+	if string(required) != `["email","password","role","username"]` {
+		t.Errorf("expected every field of the signup to be required, got %s", required)
+	}
+	if _, ok := doc.Components.Schemas["UserResp"]["properties"].(map[string]interface{})["password"]; ok {
+		t.Errorf("the signup response must not have a password")
+	}
+
+	resp = httptest.NewRecorder()
+	newServer().ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/docs", nil))
+	if resp.Code != http.StatusOK || !strings.Contains(resp.Body.String(), "/openapi.json") {
+		t.Errorf("expected the Swagger UI of /openapi.json, got %d %s", resp.Code, resp.Body)
+	}
+}
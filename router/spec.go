@@ -0,0 +1,125 @@
+package router
+
+import (
+	//user defined packages
+	"online/dto"
+	"online/middleware"
+	"online/openapi"
+
+	//Inbuild packages
+	"net/http"
+	"sort"
+	"strings"
+
+	//Third party packages
+	"github.com/labstack/echo"
+)
+
+// Description of the API, served at /openapi.json
+var Info = openapi.Info{
+	Title:       "Online purchase API",
+	Version:     "1.0.0",
+	Description: "Laptop shop: signup and login, products managed by the admins, orders and payments of the users.",
+}
+
+// Every route registered by this package, the deprecated aliases being added
+// from Successors. TestSpecCoversRoutes fails when a route is added without
+// its entry here.
+var Routes = append([]openapi.Route{
+	{Method: http.MethodGet, Path: "/healthz", Tag: "health", Summary: "Liveness of the process",
+		Response: map[string]interface{}{"status": openapi.Integer, "message": openapi.String}},
+	{Method: http.MethodGet, Path: "/readyz", Tag: "health", Summary: "Readiness: database ping and migrations (503 with the failed checks)",
+		Response: map[string]interface{}{"status": openapi.Integer, "message": openapi.String, "checks": openapi.Schema{"type": "object"}}},
+	{Method: http.MethodGet, Path: "/metrics", Tag: "health", Summary: "Prometheus metrics", ContentType: "text/plain"},
+	{Method: http.MethodGet, Path: "/openapi.json", Tag: "docs", Summary: "This OpenAPI document"},
+	{Method: http.MethodGet, Path: "/docs", Tag: "docs", Summary: "Swagger UI of this document", ContentType: echo.MIMETextHTML},
+}, apiRoutes(v1Routes)...)
+
+// Routes under APIPrefix
+var v1Routes = []openapi.Route{
+	{Method: http.MethodPost, Path: "/api/v1/users", Tag: "auth", Summary: "Sign up a new admin or user",
+		Request:  dto.SignupReq{},
+		Response: map[string]interface{}{"status": openapi.Integer, "message": openapi.String, "user data": dto.UserResp{}}},
+	{Method: http.MethodPost, Path: "/api/v1/sessions", Tag: "auth", Summary: "Log in and get the token of the user",
+		Request:  dto.LoginReq{},
+		Response: map[string]interface{}{"status": openapi.Integer, "message": openapi.String, "token": openapi.String},
+		Errors:   []int{http.StatusNotFound}},
+
+	{Method: http.MethodGet, Path: "/api/v1/products", Tag: "products", Summary: "List the products",
+		Auth: true, Permission: string(middleware.ReadProducts), Query: dto.ProductListReq{},
+		Response: map[string]interface{}{"status": openapi.Integer, "Products": []dto.ProductResp{}, "next_cursor": openapi.String, "total_count": openapi.Integer}},
+	{Method: http.MethodGet, Path: "/api/v1/products/search", Tag: "products", Summary: "Search the products, with the brand and RAM facets",
+		Auth: true, Permission: string(middleware.ReadProducts), Query: dto.ProductSearchReq{},
+		Response: map[string]interface{}{"status": openapi.Integer, "Products": []dto.ProductResp{}, "total_count": openapi.Integer, "facets": dto.FacetsResp{}}},
+	{Method: http.MethodPost, Path: "/api/v1/products", Tag: "products", Summary: "Add a product",
+		Auth: true, Permission: string(middleware.WriteProducts), Request: dto.ProductReq{},
+		Response: map[string]interface{}{"status": openapi.Integer, "message": openapi.String}},
+	{Method: http.MethodPatch, Path: "/api/v1/products/:product_id", Tag: "products", Summary: "Change the given details of a product",
+		Auth: true, Permission: string(middleware.WriteProducts), Request: dto.ProductUpdateReq{},
+		Response: map[string]interface{}{"status": openapi.Integer, "message": openapi.String},
+		Errors:   []int{http.StatusNotFound}},
+	{Method: http.MethodDelete, Path: "/api/v1/products/:product_id", Tag: "products", Summary: "Delete a product",
+		Auth: true, Permission: string(middleware.WriteProducts),
+		Response: map[string]interface{}{"status": openapi.Integer, "message": openapi.String},
+		Errors:   []int{http.StatusNotFound}},
+
+	{Method: http.MethodGet, Path: "/api/v1/orders", Tag: "orders", Summary: "List the orders of the user, or every order with orders:read_all",
+		Auth: true, Permission: string(middleware.ReadOrders) + " or " + string(middleware.ReadAllOrders), Query: dto.OrderListReq{},
+		Response: map[string]interface{}{"status": openapi.Integer, "message": openapi.String, "Orders": []dto.OrderResp{}, "next_cursor": openapi.String, "total_count": openapi.Integer}},
+	{Method: http.MethodPost, Path: "/api/v1/orders", Tag: "orders", Summary: "Order a product, with the price of its RAM and of a DVD RW drive (3000)",
+		Auth: true, Permission: string(middleware.WriteOrders), Request: dto.OrderReq{},
+		Response: map[string]interface{}{"status": openapi.Integer, "message": openapi.String, "click here to get a order status": openapi.String},
+		Errors:   []int{http.StatusNotFound}},
+	{Method: http.MethodDelete, Path: "/api/v1/orders/:order_id", Tag: "orders", Summary: "Cancel an order, refunded if it was paid",
+		Auth: true, Permission: string(middleware.WriteOrders),
+		Response: map[string]interface{}{"status": openapi.Integer, "message": openapi.String},
+		Errors:   []int{http.StatusNotFound, http.StatusConflict}},
+	{Method: http.MethodGet, Path: "/api/v1/orders/:order_id/timeline", Tag: "orders", Summary: "Changes of the state of an order, oldest first",
+		Auth: true, Permission: string(middleware.ReadOrders) + " or " + string(middleware.ReadAllOrders),
+		Response: map[string]interface{}{"status": openapi.Integer, "order_status": openapi.String, "Timeline": []dto.OrderEventResp{}},
+		Errors:   []int{http.StatusNotFound}},
+	{Method: http.MethodPost, Path: "/api/v1/orders/:order_id/payments", Tag: "orders", Summary: "Pay the total price of an order",
+		Auth: true, Permission: string(middleware.WriteOrders), Request: dto.PaymentReq{},
+		Response: map[string]interface{}{"status": openapi.Integer, "Orders": openapi.String},
+		Errors:   []int{http.StatusNotFound, http.StatusConflict}},
+
+	{Method: http.MethodGet, Path: "/api/v1/orders/:order_id/status", Tag: "order status", Summary: "Status of an order",
+		Auth: true, Permission: string(middleware.ReadOrderStatus),
+		Response: map[string]interface{}{"status": openapi.Integer, "Order Status": dto.OrderStatusResp{}},
+		Errors:   []int{http.StatusNotFound}},
+	{Method: http.MethodPut, Path: "/api/v1/orders/:order_id/status", Tag: "order status", Summary: "Move an order to its next state (packed, shipped, delivered, returned or refunded)",
+		Auth: true, Permission: string(middleware.WriteOrderStatus), Request: dto.OrderStatusReq{},
+		Response: map[string]interface{}{"status": openapi.Integer, "message": openapi.String},
+		Errors:   []int{http.StatusNotFound, http.StatusConflict}},
+	{Method: http.MethodGet, Path: "/api/v1/order-statuses", Tag: "order status", Summary: "List the status of every order",
+		Auth: true, Permission: string(middleware.ReadAllOrderStatus), Query: dto.OrderStatusListReq{},
+		Response: map[string]interface{}{"status": openapi.Integer, "message": openapi.String, "Order Statuses": []dto.OrderStatusResp{}, "next_cursor": openapi.String, "total_count": openapi.Integer}},
+}
+
+// The routes, followed by the deprecated aliases of Successors
+func apiRoutes(routes []openapi.Route) []openapi.Route {
+	byKey := map[string]openapi.Route{}
+	for _, route := range routes {
+		byKey[route.Key()] = route
+	}
+	aliases := make([]string, 0, len(Successors))
+	for alias := range Successors {
+		aliases = append(aliases, alias)
+	}
+	//Same document on every start
+	sort.Strings(aliases)
+	for _, alias := range aliases {
+		route := byKey[Successors[alias]]
+		route.Method, route.Path, _ = strings.Cut(alias, " ")
+		route.Summary += " (deprecated, use " + Successors[alias] + ")"
+		route.Deprecated = true
+		routes = append(routes, route)
+	}
+	return routes
+}
+
+// OpenAPI document and its Swagger UI, accessible without a token
+func DocsHandlers(app *echo.Echo) {
+	app.GET("/openapi.json", openapi.Handler(openapi.Build(Info, Routes)))
+	app.GET("/docs", openapi.UI(Info.Title, "/openapi.json"))
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	//user defined package(s)
+	"online/Lookup"
+	"online/config"
+	"online/driver"
+	"online/logs"
+	"online/seed"
+
+	//Inbuild package(s)
+	"flag"
+	"fmt"
+	"os"
+)
+
+const seedUsage = `Usage: online seed [--database main|test] <fixture.yaml|fixture.json>
+
+Upserts the users, products (with their RAM options) and sample orders of the
+fixture file, e.g. seed/demo.yaml. Seeding again with the same file changes nothing.
+
+Flags:
+`
+
+// Seed subcommand, returns the exit code
+func seedDatabase(args []string) int {
+	flags := flag.NewFlagSet("seed", flag.ContinueOnError)
+	database := flags.String("database", "main", "database to seed: 'main' (DB_NAME) or 'test' (TEST_DBNAME)")
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), seedUsage)
+		flags.PrintDefaults()
+	}
+	config.RegisterFlags(flags)
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	cfg, err := config.Parse(flags)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error :", err)
+		return 2
+	}
+	if err := logs.Setup(cfg.Log); err != nil {
+		fmt.Fprintln(os.Stderr, "Error : log output:", err)
+		return 2
+	}
+	defer logs.Close()
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return 2
+	}
+	fixture, err := seed.Load(flags.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error :", err)
+		return 1
+	}
+
+	Db, err := connectDatabase(cfg.Database, *database)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error :", err)
+		return 1
+	}
+	defer driver.Close(Db)
+	if err := Lookup.UpdateDatabase(Db); err != nil {
+		fmt.Fprintln(os.Stderr, "Error :", err)
+		return 1
+	}
+	report, err := seed.Apply(Db, fixture)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error :", err)
+		return 1
+	}
+	fmt.Printf("seeded %s: %d created, %d updated, %d orders kept in their state\n", flags.Arg(0), report.Created, report.Updated, report.Kept)
+	return 0
+}
@@ -0,0 +1,238 @@
+package seed
+
+import (
+	//user defined package(s)
+	"online/models"
+
+	//Inbuild package(s)
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	//Third party package(s)
+	"golang.org/x/crypto/bcrypt"
+	"gopkg.in/yaml.v3"
+	"gorm.io/gorm"
+)
+
+// Demo data loaded by the seed command. YAML is a superset of JSON, so the
+// same fixture can be written in either format.
+type Fixture struct {
+	Users    []User    `yaml:"users"`
+	Products []Product `yaml:"products"`
+	Orders   []Order   `yaml:"orders"`
+}
+
+// A user, identified by email
+type User struct {
+	Username string `yaml:"username"`
+	Email    string `yaml:"email"`
+	Password string `yaml:"password"`
+	Role     string `yaml:"role"`
+}
+
+// A product brand with its RAM options, each option is stored as a product
+// identified by brand name and RAM capacity
+type Product struct {
+	BrandName    string   `yaml:"brand_name"`
+	ProductPrice string   `yaml:"product_price"`
+	Options      []Option `yaml:"options"`
+}
+
+// A RAM option of a product
+type Option struct {
+	RamCapacity string `yaml:"ram_capacity"`
+	RamPrice    string `yaml:"ram_price"`
+}
+
+// A sample order of a product option, identified by the ordering user, the
+// product option and the phone number
+type Order struct {
+	Email       string `yaml:"email"`
+	BrandName   string `yaml:"brand_name"`
+	RamCapacity string `yaml:"ram_capacity"`
+	DvdRwDrive  bool   `yaml:"dvd_rw_drive"`
+	Name        string `yaml:"name"`
+	Address     string `yaml:"address"`
+	PhoneNumber string `yaml:"phone_number"`
+	Paid        bool   `yaml:"paid"`
+}
+
+// Number of rows created and updated by Apply, and of the orders kept in a
+// state the state machine doesn't let the fixture move them from
+type Report struct {
+	Created int
+	Updated int
+	Kept    int
+}
+
+// Read a YAML or JSON fixture file
+func Load(file string) (fixture Fixture, err error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return fixture, err
+	}
+	if err := yaml.Unmarshal(content, &fixture); err != nil {
+		return fixture, fmt.Errorf("parse %s: %w", file, err)
+	}
+	return fixture, nil
+}
+
+// Upsert the fixture into the database in a single transaction. Running it
+// again with the same fixture changes nothing.
+func Apply(Db *gorm.DB, fixture Fixture) (report Report, err error) {
+	err = Db.Transaction(func(tx *gorm.DB) error {
+		users := map[string]models.User{}
+		for _, user := range fixture.Users {
+			saved, err := upsertUser(tx, user, &report)
+			if err != nil {
+				return fmt.Errorf("user %q: %w", user.Email, err)
+			}
+			users[saved.Email] = saved
+		}
+		products := map[string]models.ProductInfo{}
+		for _, product := range fixture.Products {
+			for _, option := range product.Options {
+				saved, err := upsertProduct(tx, product, option, &report)
+				if err != nil {
+					return fmt.Errorf("product %q %q: %w", product.BrandName, option.RamCapacity, err)
+				}
+				products[saved.BrandName+"/"+saved.RamCapacity] = saved
+			}
+		}
+		for _, order := range fixture.Orders {
+			user, ok := users[order.Email]
+			if !ok {
+				return fmt.Errorf("order of %q: user is not in the fixture", order.Email)
+			}
+			product, ok := products[order.BrandName+"/"+order.RamCapacity]
+			if !ok {
+				return fmt.Errorf("order of %q: product %q %q is not in the fixture", order.Email, order.BrandName, order.RamCapacity)
+			}
+			if err := upsertOrder(tx, user, product, order, &report); err != nil {
+				return fmt.Errorf("order of %q: %w", order.Email, err)
+			}
+		}
+		return nil
+	})
+	return report, err
+}
+
+func upsertUser(tx *gorm.DB, data User, report *Report) (user models.User, err error) {
+	if data.Email == "" || data.Password == "" {
+		return user, errors.New("email and password are required")
+	}
+	var role models.Roles
+	if err := tx.Where("role = ?", data.Role).First(&role).Error; err != nil {
+		return user, fmt.Errorf("role %q: %w", data.Role, err)
+	}
+	//Find instead of First, a missing row is expected and not worth logging
+	result := tx.Where("email = ?", data.Email).Limit(1).Find(&user)
+	if result.Error != nil {
+		return user, result.Error
+	}
+	found := result.RowsAffected > 0
+	passwordMatches := found && bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(data.Password)) == nil
+	if found && passwordMatches && user.Username == data.Username && user.RoleId == role.RoleId {
+		return user, nil
+	}
+	if !passwordMatches {
+		password, err := bcrypt.GenerateFromPassword([]byte(data.Password), bcrypt.DefaultCost)
+		if err != nil {
+			return user, err
+		}
+		user.Password = string(password)
+	}
+	user.Username, user.Email, user.RoleId = data.Username, data.Email, role.RoleId
+	if found {
+		report.Updated++
+		return user, tx.Save(&user).Error
+	}
+	report.Created++
+	return user, tx.Create(&user).Error
+}
+
+func upsertProduct(tx *gorm.DB, data Product, option Option, report *Report) (product models.ProductInfo, err error) {
+	result := tx.Where("brand_name = ? AND ram_capacity = ?", data.BrandName, option.RamCapacity).Limit(1).Find(&product)
+	if result.Error != nil {
+		return product, result.Error
+	}
+	found := result.RowsAffected > 0
+	if found && product.ProductPrice == data.ProductPrice && product.RamPrice == option.RamPrice {
+		return product, nil
+	}
+	product.BrandName, product.ProductPrice = data.BrandName, data.ProductPrice
+	product.RamCapacity, product.RamPrice = option.RamCapacity, option.RamPrice
+	if found {
+		report.Updated++
+		return product, tx.Save(&product).Error
+	}
+	report.Created++
+	return product, tx.Create(&product).Error
+}
+
+func upsertOrder(tx *gorm.DB, user models.User, product models.ProductInfo, data Order, report *Report) error {
+	var order models.OrderProductInfo
+	result := tx.Where("user_id = ? AND brand_name = ? AND ram_capacity = ? AND phone_number = ?",
+		user.UserId, product.BrandName, product.RamCapacity, data.PhoneNumber).Limit(1).Find(&order)
+	if result.Error != nil {
+		return result.Error
+	}
+	found := result.RowsAffected > 0
+
+	//Same price calculation as the AddOrder handler
+	productPrice, _ := strconv.Atoi(product.ProductPrice)
+	ramPrice, _ := strconv.Atoi(product.RamPrice)
+	total := productPrice + ramPrice
+	if data.DvdRwDrive {
+		total += 3000
+	}
+	unchanged := found && order.ProductPrice == product.ProductPrice && order.RamPrice == product.RamPrice &&
+		order.DvdRwDrive == data.DvdRwDrive && order.Name == data.Name && order.Address == data.Address &&
+		order.TotalPrice == strconv.Itoa(total)
+
+	//The timeline of the order records its placement and the seeded state
+	var events []models.OrderEvent
+	if !found {
+		order.Status, order.PaymentStatus = models.OrderPendingPayment, models.OrderPendingPayment.PaymentStatus()
+		events = append(events, models.OrderEvent{To: models.OrderPendingPayment, ActorId: user.UserId, ActorRole: "user", Note: "order placed"})
+	}
+	state := models.OrderPendingPayment
+	if data.Paid {
+		state = models.OrderPaid
+	}
+	//An order moved on since (e.g. shipped or cancelled) keeps its state
+	if from := order.Status; from != state {
+		if err := order.Transition(state); err != nil {
+			report.Kept++
+		} else {
+			unchanged = false
+			events = append(events, models.OrderEvent{From: from, To: state, ActorRole: "system", Note: "seed"})
+		}
+	}
+	if unchanged {
+		return nil
+	}
+	order.UserId, order.BrandName, order.RamCapacity, order.PhoneNumber = user.UserId, product.BrandName, product.RamCapacity, data.PhoneNumber
+	order.ProductPrice, order.RamPrice, order.DvdRwDrive = product.ProductPrice, product.RamPrice, data.DvdRwDrive
+	order.Name, order.Address, order.TotalPrice = data.Name, data.Address, strconv.Itoa(total)
+	if found {
+		report.Updated++
+		if err := tx.Save(&order).Error; err != nil {
+			return err
+		}
+	} else {
+		report.Created++
+		if err := tx.Create(&order).Error; err != nil {
+			return err
+		}
+	}
+	for index := range events {
+		events[index].OrderId = order.OrderId
+	}
+	if len(events) == 0 {
+		return nil
+	}
+	return tx.Create(&events).Error
+}
@@ -0,0 +1,107 @@
+package seed
+
+import (
+	//User defined package(s)
+	"online/Lookup"
+	"online/models"
+
+	//Inbuild package(s)
+	"testing"
+
+	//Third party package(s)
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// Open a migrated in-memory database private to the test
+func openDb(t *testing.T) *gorm.DB {
+	t.Helper()
+	Db, err := gorm.Open(sqlite.Open("file:"+t.Name()+"?mode=memory&cache=shared"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	sqlDb, _ := Db.DB()
+	t.Cleanup(func() { sqlDb.Close() })
+	if err := Lookup.UpdateDatabase(Db); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	return Db
+}
+
+func TestApplyDemo(t *testing.T) {
+	Db := openDb(t)
+	fixture, err := Load("demo.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := Apply(Db, fixture)
+	if err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	var users, products, orders, paid, events int64
+	Db.Model(&models.User{}).Count(&users)
+	Db.Model(&models.ProductInfo{}).Count(&products)
+	Db.Model(&models.OrderProductInfo{}).Count(&orders)
+	Db.Model(&models.OrderProductInfo{}).Where("status = ?", models.OrderPaid).Count(&paid)
+	Db.Model(&models.OrderEvent{}).Count(&events)
+	if report.Created != int(users+products+orders) || report.Updated != 0 {
+		t.Fatalf("unexpected report %+v for %d users, %d products and %d orders", report, users, products, orders)
+	}
+	//Each order placed, and the paid ones paid
+	if orders != int64(len(fixture.Orders)) || paid != 2 || events != orders+paid {
+		t.Fatalf("expected %d orders, 2 of them paid, got %d orders and %d paid (%d events)", len(fixture.Orders), orders, paid, events)
+	}
+
+	//Seeding again changes nothing
+	if report, err := Apply(Db, fixture); err != nil || report.Created != 0 || report.Updated != 0 || report.Kept != 0 {
+		t.Fatalf("second apply should be a no-op, got %+v (%v)", report, err)
+	}
+
+	//Orders moved on since keep their state, the fixture can't rewind them
+	var shipped, cancelled models.OrderProductInfo
+	Db.Where("status = ?", models.OrderPaid).First(&shipped)
+	Db.Where("status = ?", models.OrderPendingPayment).First(&cancelled)
+	Db.Model(&shipped).Update("status", models.OrderShipped)
+	Db.Model(&cancelled).Updates(map[string]interface{}{"status": models.OrderCancelled, "payment_status": "none"})
+	if report, err := Apply(Db, fixture); err != nil || report.Updated != 0 || report.Kept != 2 {
+		t.Fatalf("expected the 2 orders kept, got %+v (%v)", report, err)
+	}
+	for _, order := range []models.OrderProductInfo{shipped, cancelled} {
+		var kept models.OrderProductInfo
+		Db.First(&kept, order.OrderId)
+		if kept.Status == models.OrderPaid || kept.Status == models.OrderPendingPayment {
+			t.Fatalf("order %d was moved back to %s", order.OrderId, kept.Status)
+		}
+	}
+	var after int64
+	if Db.Model(&models.OrderEvent{}).Count(&after); after != events {
+		t.Fatalf("expected no new event, got %d more", after-events)
+	}
+
+	//A changed price updates the product in place
+	fixture.Products[0].ProductPrice = "1"
+	if report, err := Apply(Db, fixture); err != nil || report.Created != 0 || report.Updated == 0 {
+		t.Fatalf("expected updates only, got %+v (%v)", report, err)
+	}
+	Db.Model(&models.ProductInfo{}).Count(&products)
+	if products != int64(countOptions(fixture.Products)) {
+		t.Fatalf("products were duplicated: %d", products)
+	}
+}
+
+func TestApplyUnknownUser(t *testing.T) {
+	Db := openDb(t)
+	fixture := Fixture{Orders: []Order{{Email: "nobody@demo.com", BrandName: "dell", RamCapacity: "8GB"}}}
+	if _, err := Apply(Db, fixture); err == nil {
+		t.Fatal("expected an error for an order of a user missing from the fixture")
+	}
+}
+
+func countOptions(products []Product) (count int) {
+	for _, product := range products {
+		count += len(product.Options)
+	}
+	return count
+}
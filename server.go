@@ -0,0 +1,47 @@
+package main
+
+import (
+	//user defined package(s)
+	"online/logs"
+
+	//Inbuild package(s)
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	//Third party package(s)
+	"github.com/labstack/echo"
+)
+
+// Serve the application until ctx is done (on SIGINT or SIGTERM), then stop
+// accepting connections and wait up to timeout for the in-flight requests to
+// finish. Requests still running after the deadline are cut off.
+func serve(ctx context.Context, app *echo.Echo, addr string, timeout time.Duration) error {
+	log := logs.Log()
+	stopped := make(chan error, 1)
+	go func() {
+		stopped <- app.Start(addr)
+	}()
+
+	select {
+	case err := <-stopped:
+		//The server never started, e.g. the address is already in use
+		return err
+	case <-ctx.Done():
+	}
+
+	log.Info("Shutting down, draining the in-flight requests", "timeout", timeout.String())
+	drainCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := app.Shutdown(drainCtx); err != nil {
+		app.Close()
+		return fmt.Errorf("drain in-flight requests: %w", err)
+	}
+	if err := <-stopped; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	log.Info("Every in-flight request is finished")
+	return nil
+}
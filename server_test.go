@@ -0,0 +1,84 @@
+package main
+
+import (
+	//Inbuild package(s)
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	//Third party package(s)
+	"github.com/labstack/echo"
+)
+
+// Start serving an application whose '/slow' route takes delay, on a free port
+func startSlowServer(t *testing.T, delay, timeout time.Duration) (url string, cancel context.CancelFunc, done chan error) {
+	t.Helper()
+	app := echo.New()
+	app.HideBanner, app.HidePort = true, true
+	app.GET("/slow", func(c echo.Context) error {
+		time.Sleep(delay)
+		return c.String(http.StatusOK, "done")
+	})
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	app.Listener = listener
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done = make(chan error, 1)
+	go func() { done <- serve(ctx, app, listener.Addr().String(), timeout) }()
+	return "http://" + listener.Addr().String(), cancel, done
+}
+
+func TestServeDrainsInFlightRequests(t *testing.T) {
+	url, cancel, done := startSlowServer(t, 300*time.Millisecond, 5*time.Second)
+
+	type result struct {
+		body string
+		err  error
+	}
+	response := make(chan result, 1)
+	go func() {
+		resp, err := http.Get(url + "/slow")
+		if err != nil {
+			response <- result{err: err}
+			return
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		response <- result{string(body), err}
+	}()
+
+	//Shut down while the request is in flight
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+
+	if got := <-response; got.err != nil || got.body != "done" {
+		t.Fatalf("in-flight request should finish, got %q (%v)", got.body, got.err)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("serve: %v", err)
+	}
+	if _, err := http.Get(url + "/slow"); err == nil {
+		t.Fatal("expected new connections to be refused after shutdown")
+	}
+}
+
+func TestServeDrainDeadline(t *testing.T) {
+	url, cancel, done := startSlowServer(t, 2*time.Second, 100*time.Millisecond)
+	go http.Get(url + "/slow")
+	time.Sleep(100 * time.Millisecond)
+
+	start := time.Now()
+	cancel()
+	if err := <-done; err == nil {
+		t.Fatal("expected an error for requests still running after the deadline")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("shutdown should stop waiting at the deadline, took %s", elapsed)
+	}
+}
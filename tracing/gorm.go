@@ -0,0 +1,84 @@
+package tracing
+
+import (
+	//Inbuild package(s)
+	"context"
+	"errors"
+
+	//Third party package(s)
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// GORM plugin opening a client span for every query, as a child of the span
+// in the context of the statement (see gorm.DB.WithContext)
+type GormPlugin struct{}
+
+var _ gorm.Plugin = GormPlugin{}
+
+func (GormPlugin) Name() string {
+	return "tracing"
+}
+
+// Register the callbacks around every kind of query
+func (GormPlugin) Initialize(Db *gorm.DB) error {
+	callbacks := Db.Callback()
+	return errors.Join(
+		callbacks.Create().Before("*").Register("tracing:before_create", startSpan("create")),
+		callbacks.Create().After("*").Register("tracing:after_create", endSpan),
+		callbacks.Query().Before("*").Register("tracing:before_query", startSpan("query")),
+		callbacks.Query().After("*").Register("tracing:after_query", endSpan),
+		callbacks.Update().Before("*").Register("tracing:before_update", startSpan("update")),
+		callbacks.Update().After("*").Register("tracing:after_update", endSpan),
+		callbacks.Delete().Before("*").Register("tracing:before_delete", startSpan("delete")),
+		callbacks.Delete().After("*").Register("tracing:after_delete", endSpan),
+		callbacks.Row().Before("*").Register("tracing:before_row", startSpan("row")),
+		callbacks.Row().After("*").Register("tracing:after_row", endSpan),
+		callbacks.Raw().Before("*").Register("tracing:before_raw", startSpan("raw")),
+		callbacks.Raw().After("*").Register("tracing:after_raw", endSpan),
+	)
+}
+
+// Key of the context a query started from, restored once its span ends so
+// the next query of the statement isn't a child of the previous one
+type parentKey struct{}
+
+// Start the span of a query, kept in the context of the statement
+func startSpan(operation string) func(*gorm.DB) {
+	return func(Db *gorm.DB) {
+		if Db.Statement.Context == nil {
+			return
+		}
+		ctx, _ := tracer().Start(Db.Statement.Context, "gorm."+operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(attribute.String("db.system", Db.Dialector.Name())))
+		Db.Statement.Context = context.WithValue(ctx, parentKey{}, Db.Statement.Context)
+	}
+}
+
+// End the span of a query with its SQL, table, affected rows and error
+func endSpan(Db *gorm.DB) {
+	if Db.Statement.Context == nil {
+		return
+	}
+	parent, ok := Db.Statement.Context.Value(parentKey{}).(context.Context)
+	if !ok {
+		return
+	}
+	span := trace.SpanFromContext(Db.Statement.Context)
+	Db.Statement.Context = parent
+	defer span.End()
+	span.SetAttributes(
+		semconv.DBStatementKey.String(Db.Statement.SQL.String()),
+		semconv.DBSQLTableKey.String(Db.Statement.Table),
+		attribute.Int64("db.rows_affected", Db.Statement.RowsAffected),
+	)
+	//A missing row is an answer of the query, not a failure
+	if err := Db.Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
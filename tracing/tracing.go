@@ -0,0 +1,93 @@
+package tracing
+
+import (
+	//user defined package(s)
+	"online/apierror"
+	"online/config"
+	"online/logs"
+
+	//Inbuild package(s)
+	"context"
+	"fmt"
+
+	//Third party package(s)
+	"github.com/labstack/echo"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Name of the tracer creating the spans of the application
+const name = "online"
+
+// Set up the exporter of the spans. Without an endpoint the default no-op
+// tracer provider stays in place, so spans cost next to nothing. The returned
+// function flushes the pending spans and stops the exporter.
+func Setup(cfg config.Tracing) (func(context.Context) error, error) {
+	//Trace context of the callers is honoured even when nothing is exported
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	if cfg.Endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracehttp.New(context.Background(), otlptracehttp.WithEndpointURL(cfg.Endpoint))
+	if err != nil {
+		return nil, fmt.Errorf("OTLP exporter: %w", err)
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(cfg.ServiceName)))
+	if err != nil {
+		return nil, fmt.Errorf("trace resource: %w", err)
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// Tracer of the current provider
+func tracer() trace.Tracer {
+	return otel.Tracer(name)
+}
+
+// Open a server span for every request, carrying on the trace of the caller.
+// The context of the request holds the span, so the repository queries of
+// the handlers become its children.
+func Middleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := c.Request()
+		route := c.Path()
+		if route == "" {
+			route = "unmatched"
+		}
+		ctx := otel.GetTextMapPropagator().Extract(req.Context(), propagation.HeaderCarrier(req.Header))
+		ctx, span := tracer().Start(ctx, req.Method+" "+route,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(req.Method),
+				semconv.HTTPRoute(route),
+				semconv.URLPath(req.URL.Path),
+			))
+		defer span.End()
+		c.SetRequest(req.WithContext(ctx))
+
+		//Trace id on the log lines of the request
+		if id := span.SpanContext().TraceID(); id.IsValid() {
+			logs.SetRequest(c, logs.Request(c).With("trace_id", id.String()))
+		}
+
+		status := apierror.Serve(c, next)
+		span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+		if status >= 500 {
+			span.SetStatus(codes.Error, fmt.Sprintf("status %d", status))
+		}
+		return nil
+	}
+}
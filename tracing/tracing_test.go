@@ -0,0 +1,152 @@
+package tracing
+
+import (
+	//user defined package(s)
+	"online/config"
+
+	//Inbuild package(s)
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	//Third party package(s)
+	"github.com/glebarez/sqlite"
+	"github.com/labstack/echo"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// Record the ended spans of the test
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	if _, err := Setup(config.Tracing{}); err != nil {
+		t.Fatal(err)
+	}
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return recorder
+}
+
+// Value of an attribute of a span
+func attributeOf(span sdktrace.ReadOnlySpan, key attribute.Key) attribute.Value {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestSpans(t *testing.T) {
+	recorder := recordSpans(t)
+	Db, err := gorm.Open(sqlite.Open("file:"+t.Name()+"?mode=memory&cache=shared"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDb, _ := Db.DB()
+	t.Cleanup(func() { sqlDb.Close() })
+	if err := Db.Use(GormPlugin{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := Db.Exec("CREATE TABLE products (id integer)").Error; err != nil {
+		t.Fatal(err)
+	}
+
+	e := echo.New()
+	e.Use(Middleware)
+	e.GET("/products/:id", func(c echo.Context) error {
+		var count int64
+		ctx := c.Request().Context()
+		Db.WithContext(ctx).Table("products").Count(&count)
+		Db.WithContext(ctx).Exec("SELECT * FROM missing")
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"status": 500})
+	})
+
+	//Continue the trace of the caller
+	const traceId = "4bf92f3577b34da6a3ce929d0e0e4736"
+	req := httptest.NewRequest(http.MethodGet, "/products/3", nil)
+	req.Header.Set("traceparent", "00-"+traceId+"-00f067aa0ba902b7-01")
+	e.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := recorder.Ended()
+	if len(spans) != 4 {
+		t.Fatalf("expected 3 query spans and the request span, got %d", len(spans))
+	}
+	server := spans[len(spans)-1]
+	if server.Name() != "GET /products/:id" || server.SpanContext().TraceID().String() != traceId {
+		t.Fatalf("expected the request span in the trace of the caller, got %q in %s", server.Name(), server.SpanContext().TraceID())
+	}
+	if server.Status().Code != codes.Error || attributeOf(server, "http.response.status_code").AsInt64() != 500 {
+		t.Errorf("expected a failed request span, got %v %v", server.Status(), server.Attributes())
+	}
+
+	count, failed := spans[1], spans[2]
+	for _, query := range []sdktrace.ReadOnlySpan{count, failed} {
+		if query.Parent().SpanID() != server.SpanContext().SpanID() {
+			t.Errorf("expected %q to be a child of the request span", query.Name())
+		}
+	}
+	if count.Name() != "gorm.row" && count.Name() != "gorm.query" {
+		t.Errorf("unexpected span of the count %q", count.Name())
+	}
+	if !strings.Contains(attributeOf(count, "db.statement").AsString(), "count(*)") {
+		t.Errorf("expected the SQL of the count, got %v", count.Attributes())
+	}
+	if failed.Name() != "gorm.raw" || failed.Status().Code != codes.Error {
+		t.Errorf("expected a failed raw query, got %q %v", failed.Name(), failed.Status())
+	}
+}
+
+func TestSetupWithoutEndpoint(t *testing.T) {
+	shutdown, err := Setup(config.Tracing{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := otel.GetTracerProvider().(*sdktrace.TracerProvider); ok {
+		t.Fatal("expected the no-op tracer provider without an endpoint")
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSetupExportsToEndpoint(t *testing.T) {
+	received := make(chan string, 1)
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case received <- r.URL.Path:
+		default:
+		}
+	}))
+	defer collector.Close()
+	previous := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	shutdown, err := Setup(config.Tracing{Endpoint: collector.URL, ServiceName: "online", SampleRatio: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, span := tracer().Start(context.Background(), "test")
+	span.End()
+	//Shutting down flushes the batch of spans
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case path := <-received:
+		if path != "/v1/traces" {
+			t.Fatalf("expected the spans on /v1/traces, got %s", path)
+		}
+	default:
+		t.Fatal("expected the spans to be exported to the endpoint")
+	}
+}
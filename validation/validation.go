@@ -0,0 +1,88 @@
+package validation
+
+import (
+	//user defined package(s)
+	"online/apierror"
+
+	//Inbuild package(s)
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+
+	//Third party package(s)
+	"github.com/go-playground/validator/v10"
+)
+
+// Validator of the request bodies, set as the echo.Validator of the server
+type Validator struct {
+	validate *validator.Validate
+}
+
+func New() *Validator {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	//Report the fields by their name in the JSON body
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+	validate.RegisterValidation("price", price)
+	return &Validator{validate: validate}
+}
+
+// Prices are whole numbers above 0, written without sign nor leading zero,
+// so they add up with strconv.Atoi and compare as strings
+func price(field validator.FieldLevel) bool {
+	value := field.Field().String()
+	if value == "" || value[0] == '0' || strings.TrimLeft(value, "0123456789") != "" {
+		return false
+	}
+	_, err := strconv.Atoi(value)
+	return err == nil
+}
+
+// Check every 'validate' tag of i, and report all the invalid fields at once
+func (v *Validator) Validate(i interface{}) error {
+	err := v.validate.Struct(i)
+	var fieldErrs validator.ValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		return err
+	}
+	details := make([]apierror.Detail, len(fieldErrs))
+	for index, fieldErr := range fieldErrs {
+		details[index] = apierror.Detail{Field: fieldErr.Field(), Message: message(fieldErr)}
+	}
+	return apierror.Validation(details...)
+}
+
+// Message of a failed rule
+func message(fieldErr validator.FieldError) string {
+	switch fieldErr.Tag() {
+	case "required":
+		return "is required"
+	case "email":
+		return "must be a valid email"
+	case "min":
+		if fieldErr.Kind() == reflect.String {
+			return "must be at least " + fieldErr.Param() + " characters"
+		}
+		return "must be at least " + fieldErr.Param()
+	case "max":
+		if fieldErr.Kind() == reflect.String {
+			return "must be at most " + fieldErr.Param() + " characters"
+		}
+		return "must be at most " + fieldErr.Param()
+	case "numeric":
+		return "must be a number"
+	case "price":
+		return "must be a whole number above 0"
+	case "e164", "e164|len=10":
+		return "must be a 10 digit or E.164 (+919876543210) phone number"
+	case "oneof":
+		return "must be one of: " + strings.Join(strings.Fields(fieldErr.Param()), ", ")
+	}
+	return "is invalid"
+}
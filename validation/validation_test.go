@@ -0,0 +1,83 @@
+package validation
+
+import (
+	//user defined package(s)
+	"online/apierror"
+	"online/dto"
+
+	//Inbuild package(s)
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	order := dto.OrderReq{
+		BrandName:    "hp",
+		ProductPrice: "20000",
+		RamCapacity:  "2GB",
+		RamPrice:     "2000",
+		Name:         "Hari",
+		Address:      "5th street",
+	}
+	tests := []struct {
+		phone string
+		valid bool
+	}{
+		{"9876543210", true},
+		{"+919876543210", true},
+		{"987654321", false},
+		{"98765abcde", false},
+		{"+0123", false},
+	}
+	validator := New()
+	for _, test := range tests {
+		order.PhoneNumber = test.phone
+		if err := validator.Validate(order); (err == nil) != test.valid {
+			t.Errorf("phone number %q: expected valid %v, got %v", test.phone, test.valid, err)
+		}
+	}
+}
+
+func TestValidatePrice(t *testing.T) {
+	tests := []struct {
+		price string
+		valid bool
+	}{
+		{"20000", true},
+		{"1", true},
+		{"0", false},
+		{"-5", false},
+		{"+5", false},
+		{"12.50", false},
+		{"0200", false},
+		{"99999999999999999999", false},
+	}
+	validator := New()
+	for _, test := range tests {
+		if err := validator.Validate(dto.PaymentReq{Payment: test.price}); (err == nil) != test.valid {
+			t.Errorf("price %q: expected valid %v, got %v", test.price, test.valid, err)
+		}
+	}
+}
+
+func TestValidateReportsEveryField(t *testing.T) {
+	err := New().Validate(dto.ProductReq{ProductPrice: "cheap", RamPrice: "2000"})
+	var apiErr *apierror.Error
+	if !errors.As(err, &apiErr) || apiErr.Status != http.StatusUnprocessableEntity {
+		t.Fatalf("expected a validation error, got %v", err)
+	}
+	want := []apierror.Detail{
+		{Field: "brand_name", Message: "is required"},
+		{Field: "product_price", Message: "must be a whole number above 0"},
+		{Field: "ram_capacity", Message: "is required"},
+	}
+	if len(apiErr.Details) != len(want) {
+		t.Fatalf("expected %v, got %v", want, apiErr.Details)
+	}
+	for index := range want {
+		if apiErr.Details[index] != want[index] {
+			t.Errorf("expected %v, got %v", want[index], apiErr.Details[index])
+		}
+	}
+}